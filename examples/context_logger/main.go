@@ -13,28 +13,20 @@ var (
 	logLocation = "./logs/context_example.log"
 )
 
-// LoggerFromContext creates a logger with context values.
-// This is an application-level extension of the core logger.
-func LoggerFromContext(ctx context.Context) *logger.Logger {
-	baseLogger := logger.L()
-
-	// Add application-specific context values
-	if requestID, ok := ctx.Value("request_id").(string); ok {
-		// The fix is here - we need to create a new logger.Logger wrapper around
-		// the slog.Logger that has the additional attribute
-		baseLogger = &logger.Logger{
-			Logger: baseLogger.Logger.With("request_id", requestID),
+// contextKey is the type of the context keys used by this application.
+type contextKey string
+
+// requestValues extracts the application-specific context values.
+// It is registered with AddContextExtractor, so the values are added to every
+// record logged with a context.
+func requestValues(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	for _, key := range []contextKey{"request_id", "user_id", "operation_id"} {
+		if value, ok := ctx.Value(key).(string); ok {
+			attrs = append(attrs, slog.String(string(key), value))
 		}
 	}
-
-	if userID, ok := ctx.Value("user_id").(string); ok {
-		baseLogger = &logger.Logger{
-			Logger: baseLogger.Logger.With("user_id", userID),
-		}
-	}
-
-	// Add other context values as needed for your application
-	return baseLogger
+	return attrs
 }
 
 func main() {
@@ -43,6 +35,7 @@ func main() {
 		logger.SetLevel(slog.LevelDebug),
 		logger.AddSource(),
 		logger.AddFileOutput(logLocation, 10, 3, 30, true),
+		logger.AddContextExtractor(requestValues),
 	)
 
 	defer logger.Close() // Ensure all file writers are closed properly
@@ -53,24 +46,26 @@ func main() {
 	log.Info("----------------------")
 
 	// Create a context with request ID and user ID
-	ctx := context.WithValue(context.Background(), "request_id", "req-abc-123")
-	ctx = context.WithValue(ctx, "user_id", "user-456")
-
-	// Use LoggerFromContext instead of WithContext
-	requestLogger := LoggerFromContext(ctx)
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-abc-123")
+	ctx = context.WithValue(ctx, contextKey("user_id"), "user-456")
 
 	// The request ID and user ID will automatically be included in these log entries
-	requestLogger.Info("Processing request")
-	requestLogger.Debug("Request details being parsed")
+	log.InfoContext(ctx, "Processing request")
+	log.DebugContext(ctx, "Request details being parsed")
+
+	// Store a logger for the request in the context
+	ctx = logger.NewContext(ctx, log.With("component", "api"))
 
 	// Simulate request handling
-	processRequest(ctx, requestLogger)
+	processRequest(ctx)
 
 	log.Info("Context logging example completed")
 }
 
-func processRequest(ctx context.Context, log *logger.Logger) {
-	// No need to extract request ID from context - it's already in the logger
+func processRequest(ctx context.Context) {
+	// The logger from the context is bound to it, so the request ID and user ID
+	// are included even without the *Context methods
+	log := logger.FromContext(ctx)
 
 	// Log with the context-aware logger
 	log.Info("Request processing complete", "status", "success")
@@ -82,11 +77,8 @@ func processRequest(ctx context.Context, log *logger.Logger) {
 	)
 
 	// Create a sub-context for a specific operation
-	operationCtx := context.WithValue(ctx, "operation_id", "op-789")
-
-	// Create a new logger for this operation
-	opLogger := LoggerFromContext(operationCtx)
+	operationCtx := context.WithValue(ctx, contextKey("operation_id"), "op-789")
 
 	// This log will include request_id, user_id, and operation_id
-	opLogger.Info("Operation completed", "result", "success")
+	logger.FromContext(operationCtx).Info("Operation completed", "result", "success")
 }
@@ -0,0 +1,340 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the caller resolution for the source of records, which
+// lets wrapper functions be skipped with WithCallerSkip and Helper, and the
+// logging methods that use it in place of the ones of slog.Logger.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCallerDepth is the number of frames searched for a caller that is not a helper.
+const maxCallerDepth = 32
+
+var (
+	// helperFuncs holds the names of the functions marked with Helper
+	helperFuncs sync.Map
+
+	// hasHelpers is set once a function is marked, so the common case skips the search
+	hasHelpers atomic.Bool
+)
+
+// WithCallerSkip returns a child logger that skips n additional frames when
+// it determines the source of a record. Use it in a wrapper package whose
+// functions call the logger, so the source points at the caller of the
+// wrapper instead of the wrapper itself. The skips of nested calls add up.
+//
+// Parameters:
+//   - n: The number of wrapper frames between the caller and the logging method
+//
+// Returns:
+//   - *Logger: The child logger, or the logger itself if n is 0
+func (l *Logger) WithCallerSkip(n int) *Logger {
+	if l == nil || n == 0 {
+		return l
+	}
+	child := l.derive(func(sl *slog.Logger) *slog.Logger { return sl })
+	child.callerSkip = max(l.callerSkip+n, 0)
+	return child
+}
+
+// Helper marks the calling function as a logging helper, like
+// testing.T.Helper. When the source of a record is determined, the frames of
+// helper functions are skipped, so the source and function point at the code
+// calling the helper. Helper can be called from any number of functions; it is
+// cheap, but marking a function has a small cost for every record afterwards.
+//
+//	func logRequest(r *http.Request) {
+//		logger.Helper()
+//		logger.L().Info("Request", "method", r.Method, "path", r.URL.Path)
+//	}
+func Helper() {
+	var pcs [1]uintptr
+	// Skip runtime.Callers and Helper
+	runtime.Callers(2, pcs[:])
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	if _, loaded := helperFuncs.LoadOrStore(frame.Function, struct{}{}); !loaded {
+		hasHelpers.Store(true)
+	}
+}
+
+// isHelper reports whether a function was marked with Helper.
+//
+// Parameters:
+//   - function: The fully qualified function name
+//
+// Returns:
+//   - bool: True if the function is a helper
+func isHelper(function string) bool {
+	_, ok := helperFuncs.Load(function)
+	return ok
+}
+
+// callerPC returns the program counter of the caller of a logging method, in
+// the form slog.Record expects for AddSource. The caller skip of the logger
+// and the frames of helper functions are skipped in addition.
+//
+// Parameters:
+//   - skip: The number of frames to skip, starting with the function calling callerPC
+//
+// Returns:
+//   - uintptr: The program counter, or 0 if the stack is not that deep
+func (l *Logger) callerPC(skip int) uintptr {
+	// Skip runtime.Callers and callerPC
+	skip += 2 + l.callerSkip
+
+	if !hasHelpers.Load() {
+		var pcs [1]uintptr
+		runtime.Callers(skip, pcs[:])
+		return pcs[0]
+	}
+
+	var pcs [maxCallerDepth]uintptr
+	n := runtime.Callers(skip, pcs[:])
+	for _, pc := range pcs[:n] {
+		if !onlyHelpers(pc) {
+			return pc
+		}
+	}
+	return pcs[0]
+}
+
+// onlyHelpers reports whether all functions at a program counter, including
+// the ones inlined there, are helpers.
+//
+// Parameters:
+//   - pc: The program counter
+//
+// Returns:
+//   - bool: True if every function at pc was marked with Helper
+func onlyHelpers(pc uintptr) bool {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if !isHelper(frame.Function) {
+			return false
+		}
+		if !more {
+			return true
+		}
+	}
+}
+
+// sourceFrame returns the frame reported as the source of a record. If helper
+// functions were inlined at pc, the innermost function that is not a helper is used.
+//
+// Parameters:
+//   - pc: The program counter of the record
+//
+// Returns:
+//   - runtime.Frame: The source frame
+func sourceFrame(pc uintptr) runtime.Frame {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, more := frames.Next()
+	if !hasHelpers.Load() {
+		return frame
+	}
+
+	first := frame
+	for isHelper(frame.Function) && more {
+		frame, more = frames.Next()
+	}
+	if isHelper(frame.Function) {
+		return first
+	}
+	return frame
+}
+
+// callerSource returns the source attribute written by Trace, Fatal and Panic,
+// which includes the function name in addition to the file and line.
+//
+// Parameters:
+//   - pc: The program counter of the caller
+//
+// Returns:
+//   - slog.Attr: The source attribute as "file:line (function)"
+func callerSource(pc uintptr) slog.Attr {
+	frame := sourceFrame(pc)
+	return slog.String(slog.SourceKey, fmt.Sprintf("%s:%d (%s)", frame.File, frame.Line, frame.Function))
+}
+
+// Debug logs at DEBUG level.
+// It replaces slog.Logger.Debug so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) Debug(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, msg, args)
+}
+
+// DebugContext logs at DEBUG level with the given context.
+// It replaces slog.Logger.DebugContext so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelDebug, msg, args)
+}
+
+// Info logs at INFO level.
+// It replaces slog.Logger.Info so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) Info(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, msg, args)
+}
+
+// InfoContext logs at INFO level with the given context.
+// It replaces slog.Logger.InfoContext so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelInfo, msg, args)
+}
+
+// Warn logs at WARN level.
+// It replaces slog.Logger.Warn so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) Warn(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, msg, args)
+}
+
+// WarnContext logs at WARN level with the given context.
+// It replaces slog.Logger.WarnContext so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelWarn, msg, args)
+}
+
+// Error logs at ERROR level.
+// It replaces slog.Logger.Error so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) Error(msg string, args ...any) {
+	l.log(context.Background(), slog.LevelError, msg, args)
+}
+
+// ErrorContext logs at ERROR level with the given context.
+// It replaces slog.Logger.ErrorContext so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, slog.LevelError, msg, args)
+}
+
+// Log logs at the given level with the given context.
+// It replaces slog.Logger.Log so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The level of the record
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) Log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	l.log(ctx, level, msg, args)
+}
+
+// LogAttrs logs at the given level with the given context and attributes.
+// It replaces slog.Logger.LogAttrs so the source honors WithCallerSkip and Helper.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The level of the record
+//   - msg: The message to log
+//   - attrs: Additional attributes
+//
+// Returns:
+//   - None
+func (l *Logger) LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip LogAttrs
+	r := slog.NewRecord(timeNow(), level, msg, l.callerPC(1))
+	r.AddAttrs(attrs...)
+	if l.stackAt(level) {
+		r.AddAttrs(slog.Any(StackKey, l.recordStack(r, 1)))
+	}
+	_ = l.Handler().Handle(ctx, r)
+}
+
+// log logs a record with the source of the caller of the logging method.
+//
+// Parameters:
+//   - ctx: The context for the logging operation, or nil for context.Background()
+//   - level: The level of the record
+//   - msg: The message to log
+//   - args: Additional attributes, provided as alternating keys and values
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, args []any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip log and the logging method
+	r := slog.NewRecord(timeNow(), level, msg, l.callerPC(2))
+	r.Add(args...)
+	if l.stackAt(level) {
+		r.AddAttrs(slog.Any(StackKey, l.recordStack(r, 2)))
+	}
+	_ = l.Handler().Handle(ctx, r)
+}
@@ -0,0 +1,139 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for skipping wrapper frames in the source of records.
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// wrapInfo is a wrapper function as found in helper packages.
+//
+// Parameters:
+//   - l: The logger to log with
+//   - msg: The message to log
+func wrapInfo(l *Logger, msg string) {
+	l.Info(msg)
+}
+
+// helperInfo is a wrapper function marked with Helper.
+//
+// Parameters:
+//   - l: The logger to log with
+//   - msg: The message to log
+func helperInfo(l *Logger, msg string) {
+	Helper()
+	l.Info(msg)
+}
+
+// helperTrace is a wrapper function marked with Helper that calls another helper.
+//
+// Parameters:
+//   - l: The logger to log with
+//   - msg: The message to log
+func helperTrace(l *Logger, msg string) {
+	Helper()
+	helperTraceInner(l, msg)
+}
+
+// helperTraceInner is a nested wrapper function marked with Helper.
+//
+// Parameters:
+//   - l: The logger to log with
+//   - msg: The message to log
+func helperTraceInner(l *Logger, msg string) {
+	Helper()
+	l.Trace(msg)
+}
+
+// TestWithCallerSkip tests that the source of records skips the frames of
+// wrapper functions in the text and JSON outputs.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestWithCallerSkip(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text, jsonBuf bytes.Buffer
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddWriterOutput(&jsonBuf, OutputFormat(FormatJSON)),
+		AddSource(),
+	)
+
+	wrapInfo(testLogger.WithCallerSkip(1).With("k", "v"), "skipped")
+	want := fmt.Sprintf("%s:%d", CallerFile(), CallerLine()-1)
+
+	if got := text.String(); !strings.Contains(got, "source="+want) {
+		t.Errorf("Text output = %q, want source=%s", got, want)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	if entry["source"] != want {
+		t.Errorf("JSON source = %v, want %s", entry["source"], want)
+	}
+
+	// Without the skip the wrapper is reported
+	text.Reset()
+	wrapInfo(testLogger, "not skipped")
+	if got := text.String(); strings.Contains(got, want) || !strings.Contains(got, "caller_test.go:") {
+		t.Errorf("Text output = %q, want the line of the wrapper", got)
+	}
+
+	if testLogger.WithCallerSkip(0) != testLogger {
+		t.Error("WithCallerSkip(0) should return the logger itself")
+	}
+}
+
+// TestHelper tests that functions marked with Helper are skipped in the
+// source of records, including the function name written by Trace.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestHelper(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text bytes.Buffer
+	entries := make(chan Entry, 10)
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddRecordChannelOutput(entries),
+		AddSource(),
+		SetLevel(LevelTrace),
+	)
+
+	helperInfo(testLogger, "from helper")
+	want := fmt.Sprintf("source=%s:%d", CallerFile(), CallerLine()-1)
+	if got := text.String(); !strings.Contains(got, want) {
+		t.Errorf("Text output = %q, want %s", got, want)
+	}
+	<-entries
+
+	helperTrace(testLogger, "from nested helpers")
+	want = fmt.Sprintf("%s:%d", CallerFile(), CallerLine()-1)
+	entry := <-entries
+	if entry.Source != want {
+		t.Errorf("Entry source = %q, want %q", entry.Source, want)
+	}
+	if fn, _ := entry.Attrs["source"].(string); !strings.HasSuffix(fn, "(github.com/aN0mad/go-logo/logo.TestHelper)") {
+		t.Errorf("Trace source = %q, want the function calling the helpers", fn)
+	}
+
+	// Direct calls are not affected
+	testLogger.Info("direct")
+	want = fmt.Sprintf("%s:%d", CallerFile(), CallerLine()-1)
+	if entry := <-entries; entry.Source != want {
+		t.Errorf("Entry source = %q, want %q", entry.Source, want)
+	}
+}
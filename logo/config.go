@@ -0,0 +1,324 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the declarative configuration schema which allows a
+// logger to be configured from JSON or YAML instead of code.
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative configuration of a logger.
+// Empty fields keep the defaults, so a configuration only needs to name the
+// settings it changes. It can be decoded from JSON or YAML.
+//
+// Example (YAML):
+//
+//	level: debug
+//	format: text
+//	source: true
+//	loggers:
+//	  cache.*: warn
+//	outputs:
+//	  - type: console
+//	    level: info
+//	  - type: file
+//	    path: logs/app.log
+//	    max_size: 10
+//	    format: json
+type Config struct {
+	// Level is the minimum level of the logger (e.g., "debug", "info", "trace")
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+
+	// Format is the default format of all outputs: "text", "json" or "json-pretty"
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Color enables or disables colored console output
+	Color *bool `json:"color,omitempty" yaml:"color,omitempty"`
+
+	// Source adds source file and line information to log entries
+	Source bool `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// StackTraces adds stack traces to fatal log entries
+	StackTraces bool `json:"stack_traces,omitempty" yaml:"stack_traces,omitempty"`
+
+	// StackTracesAt adds stack traces to all log entries at or above the level (see StackTracesAt)
+	StackTracesAt string `json:"stack_traces_at,omitempty" yaml:"stack_traces_at,omitempty"`
+
+	// MaxStackDepth limits the number of frames in stack traces; 0 keeps the default
+	MaxStackDepth int `json:"max_stack_depth,omitempty" yaml:"max_stack_depth,omitempty"`
+
+	// Loggers maps names and patterns of named loggers to their level (see SetLevelFor)
+	Loggers map[string]string `json:"loggers,omitempty" yaml:"loggers,omitempty"`
+
+	// Outputs lists the log destinations; if empty, the console is used
+	Outputs []OutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// OutputConfig is the declarative configuration of a single output.
+type OutputConfig struct {
+	// Type is the kind of output: "console", "stderr" or "file"
+	Type string `json:"type" yaml:"type"`
+
+	// Path is the log file of a file output
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// MaxSize is the size in megabytes at which a file output is rotated
+	MaxSize int `json:"max_size,omitempty" yaml:"max_size,omitempty"`
+
+	// MaxBackups is the number of rotated files of a file output to keep
+	MaxBackups int `json:"max_backups,omitempty" yaml:"max_backups,omitempty"`
+
+	// MaxAge is the number of days rotated files of a file output are kept
+	MaxAge int `json:"max_age,omitempty" yaml:"max_age,omitempty"`
+
+	// Compress enables gzip compression of rotated files
+	Compress bool `json:"compress,omitempty" yaml:"compress,omitempty"`
+
+	// Format overrides the logger format for this output
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Level is the minimum level of this output
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+
+	// Source overrides the logger source setting for this output
+	Source *bool `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// LoadConfig reads a configuration file. The format is selected by the file
+// extension: ".json" for JSON, ".yaml" or ".yml" for YAML. Unknown fields are
+// rejected so that typos do not go unnoticed.
+//
+// Parameters:
+//   - path: The path of the configuration file
+//
+// Returns:
+//   - Config: The decoded configuration
+//   - error: An error if the file cannot be read or decoded
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+		if errors.Is(err, io.EOF) {
+			// An empty document keeps all defaults
+			err = nil
+		}
+	default:
+		return Config{}, fmt.Errorf("config %s: unsupported file extension %q, use .json, .yaml or .yml", path, ext)
+	}
+
+	if err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// FromConfigFile configures the logger from a JSON or YAML file.
+// Options passed after FromConfigFile override the settings of the file.
+// Errors in the file are reported with the offending field, e.g.
+// "outputs[1].level", and can be handled with NewLoggerE or InitE.
+//
+// Parameters:
+//   - path: The path of the configuration file
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to apply the configuration file
+func FromConfigFile(path string) LoggerOption {
+	return func(ctx *loggerContext) {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			ctx.addError(err)
+			return
+		}
+		applyConfig(ctx, cfg, "config "+path)
+	}
+}
+
+// WithConfig configures the logger from a Config value.
+// Options passed after WithConfig override its settings.
+//
+// Parameters:
+//   - cfg: The configuration to apply
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to apply the configuration
+func WithConfig(cfg Config) LoggerOption {
+	return func(ctx *loggerContext) {
+		applyConfig(ctx, cfg, "config")
+	}
+}
+
+// applyConfig applies a configuration to the logger context. Invalid fields are
+// recorded as errors naming the field and skipped.
+//
+// Parameters:
+//   - ctx: The logger context to configure
+//   - cfg: The configuration to apply
+//   - origin: Describes where the configuration came from, used in error messages
+func applyConfig(ctx *loggerContext, cfg Config, origin string) {
+	fieldError := func(field string, err error) {
+		ctx.addError(fmt.Errorf("%w: %s: %s: %v", ErrInvalidOption, origin, field, err))
+	}
+
+	if cfg.Level != "" {
+		if level, err := ParseLevel(cfg.Level); err != nil {
+			fieldError("level", err)
+		} else {
+			SetLevel(level)(ctx)
+		}
+	}
+
+	if cfg.Format != "" {
+		switch format, err := ParseFormat(cfg.Format); {
+		case err != nil:
+			fieldError("format", err)
+		case format == FormatJSON:
+			UseJSON(false)(ctx)
+		case format == FormatPrettyJSON:
+			UseJSON(true)(ctx)
+		default:
+			ctx.useJSONFormat = false
+		}
+	}
+
+	if cfg.Color != nil {
+		ctx.colorEnabled = *cfg.Color
+		ctx.colorSet = true
+	}
+	if cfg.Source {
+		AddSource()(ctx)
+	}
+	if cfg.StackTraces {
+		EnableStackTraces()(ctx)
+	}
+	if cfg.StackTracesAt != "" {
+		if level, err := ParseLevel(cfg.StackTracesAt); err != nil {
+			fieldError("stack_traces_at", err)
+		} else {
+			StackTracesAt(level)(ctx)
+		}
+	}
+	if cfg.MaxStackDepth < 0 {
+		fieldError("max_stack_depth", fmt.Errorf("depth %d is less than 1", cfg.MaxStackDepth))
+	} else if cfg.MaxStackDepth > 0 {
+		ctx.stackDepth = cfg.MaxStackDepth
+	}
+
+	// Sort the patterns so errors are reported in a stable order
+	patterns := make([]string, 0, len(cfg.Loggers))
+	for pattern := range cfg.Loggers {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		field := fmt.Sprintf("loggers[%s]", pattern)
+		if err := checkLevelPattern(pattern); err != nil {
+			fieldError(field, err)
+		} else if level, err := ParseLevel(cfg.Loggers[pattern]); err != nil {
+			fieldError(field, err)
+		} else {
+			SetLevelFor(pattern, level)(ctx)
+		}
+	}
+
+	// Configured outputs replace the default console output
+	if len(cfg.Outputs) > 0 {
+		ctx.consoleOn = false
+	}
+
+	for i, oc := range cfg.Outputs {
+		field := fmt.Sprintf("outputs[%d]", i)
+
+		opts, ok := outputConfigOptions(oc, func(name string, err error) {
+			fieldError(field+"."+name, err)
+		})
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(oc.Type) {
+		case "console":
+			AddConsoleOutput(opts...)(ctx)
+		case "stderr":
+			AddWriterOutput(os.Stderr, opts...)(ctx)
+		case "file":
+			if oc.Path == "" {
+				fieldError(field+".path", errors.New("required for file outputs"))
+				continue
+			}
+
+			// Report errors of the file output with the field they belong to
+			before := len(ctx.errs)
+			AddFileOutput(oc.Path, oc.MaxSize, oc.MaxBackups, oc.MaxAge, oc.Compress, opts...)(ctx)
+			for j := before; j < len(ctx.errs); j++ {
+				ctx.errs[j] = fmt.Errorf("%s: %s: %w", origin, field, ctx.errs[j])
+			}
+		case "":
+			fieldError(field+".type", errors.New("missing output type"))
+		default:
+			fieldError(field+".type", fmt.Errorf("unknown output type %q, use console, stderr or file", oc.Type))
+		}
+	}
+}
+
+// outputConfigOptions converts the format, level and source settings of an
+// output configuration to output options.
+//
+// Parameters:
+//   - oc: The output configuration
+//   - fieldError: Called for every invalid field with its name
+//
+// Returns:
+//   - []OutputOption: The options for the output
+//   - bool: False if any of the settings was invalid
+func outputConfigOptions(oc OutputConfig, fieldError func(name string, err error)) ([]OutputOption, bool) {
+	var opts []OutputOption
+	ok := true
+
+	if oc.Format != "" {
+		if format, err := ParseFormat(oc.Format); err != nil {
+			fieldError("format", err)
+			ok = false
+		} else {
+			opts = append(opts, OutputFormat(format))
+		}
+	}
+
+	if oc.Level != "" {
+		if level, err := ParseLevel(oc.Level); err != nil {
+			fieldError("level", err)
+			ok = false
+		} else {
+			opts = append(opts, OutputLevel(level))
+		}
+	}
+
+	if oc.Source != nil {
+		opts = append(opts, OutputSource(*oc.Source))
+	}
+
+	return opts, ok
+}
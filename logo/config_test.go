@@ -0,0 +1,199 @@
+package logo
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigFile writes a configuration file into a temporary directory.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+//   - name: The file name, whose extension selects the format
+//   - content: The file content
+//
+// Returns:
+//   - string: The path of the written file
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+// TestFromConfigFile tests configuring a logger from JSON and YAML files.
+// It verifies that logger-wide and per-output settings are applied.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFromConfigFile(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	logDir := t.TempDir()
+	files := map[string]string{
+		"logger.yaml": `
+level: debug
+format: text
+color: false
+source: true
+stack_traces: true
+outputs:
+  - type: file
+    path: ` + filepath.Join(logDir, "yaml.log") + `
+    max_size: 5
+    format: json
+    level: warn
+`,
+		"logger.json": `{
+  "level": "debug",
+  "format": "text",
+  "color": false,
+  "source": true,
+  "stack_traces": true,
+  "outputs": [
+    {"type": "file", "path": "` + filepath.ToSlash(filepath.Join(logDir, "json.log")) + `", "max_size": 5, "format": "json", "level": "warn"}
+  ]
+}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			l, err := NewLoggerE(FromConfigFile(writeConfigFile(t, name, content)))
+			if err != nil {
+				t.Fatalf("NewLoggerE() error = %v", err)
+			}
+			defer l.Close()
+
+			ctx := l.ctx
+			if ctx.logLevel != slog.LevelDebug || ctx.colorEnabled || !ctx.includeSource || !ctx.includeStackTraces || ctx.useJSONFormat {
+				t.Errorf("Logger settings not applied: level=%v color=%v source=%v stack=%v json=%v",
+					ctx.logLevel, ctx.colorEnabled, ctx.includeSource, ctx.includeStackTraces, ctx.useJSONFormat)
+			}
+
+			if len(ctx.outputs) != 1 || len(ctx.fileWriters) != 1 {
+				t.Fatalf("Config should replace the console with one file output, got %d outputs", len(ctx.outputs))
+			}
+
+			out := ctx.outputs[0]
+			if out.format != FormatJSON || !out.hasLevel || out.level != slog.LevelWarn || ctx.fileWriters[0].MaxSize != 5 {
+				t.Errorf("Output settings not applied: %+v", out)
+			}
+		})
+	}
+}
+
+// TestFromConfigFile_Errors tests the error messages of invalid configurations.
+// It verifies that every error names the offending field.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFromConfigFile_Errors(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	path := writeConfigFile(t, "logger.yml", `
+level: loud
+format: xml
+outputs:
+  - type: console
+  - type: file
+    level: verbose
+    path: app.log
+  - type: file
+  - type: syslog
+  - type: file
+    path: `+filepath.Join(t.TempDir(), "app.log")+`
+    max_backups: -1
+`)
+
+	_, err := NewLoggerE(FromConfigFile(path))
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("NewLoggerE() error = %v, want ErrInvalidOption", err)
+	}
+
+	for _, want := range []string{
+		`level: unknown level "loud"`,
+		`format: unknown format "xml"`,
+		`outputs[1].level: unknown level "verbose"`,
+		`outputs[2].path: required for file outputs`,
+		`outputs[3].type: unknown output type "syslog"`,
+		`outputs[4]: invalid logger option: AddFileOutput`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Error %q does not contain %q", err, want)
+		}
+	}
+
+	if strings.Contains(err.Error(), "outputs[0]") {
+		t.Errorf("Error %q reports the valid console output", err)
+	}
+}
+
+// TestLoadConfig tests reading configuration files that cannot be decoded.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown.json", `{"level": "info", "colour": true}`, `unknown field "colour"`},
+		{"unknown.yaml", "level: info\ncolour: true\n", "field colour not found"},
+		{"logger.toml", "level = 'info'", "unsupported file extension"},
+		{"empty.yaml", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfigFile(t, tt.name, tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadConfig() error = %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadConfig() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+// TestWithConfig tests that options after a configuration override it.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestWithConfig(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	l, err := NewLoggerE(
+		WithConfig(Config{Level: "error", Format: "json"}),
+		SetLevel(slog.LevelInfo),
+		SetConsoleOutput(&buf),
+	)
+	if err != nil {
+		t.Fatalf("NewLoggerE() error = %v", err)
+	}
+
+	l.Info("overridden level")
+	if !strings.Contains(buf.String(), `"msg":"overridden level"`) {
+		t.Errorf("Expected JSON output at INFO level, got %q", buf.String())
+	}
+}
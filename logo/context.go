@@ -0,0 +1,236 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the context extractors which add values carried in a
+// context.Context to log records, the helpers storing a Logger in a context and
+// the per-request level override.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// ContextExtractor returns the attributes to log for the values in a context,
+// such as a request ID or a tenant. It is called for every record that is
+// handled, so it should be fast and must be safe for concurrent use. It may
+// return nil if the context holds none of its values.
+type ContextExtractor func(ctx context.Context) []slog.Attr
+
+// AddContextExtractor adds a function that extracts attributes from the
+// context of every record. The context is the one passed to the *Context
+// methods such as InfoContext, or the one bound with WithContext. Extracted
+// attributes are written at the top level of a record, outside of groups
+// opened with WithGroup, and attributes added with With take precedence over
+// them. Extractors run in the order they were added.
+//
+// Parameters:
+//   - fn: The function extracting attributes from a context
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to add a context extractor
+func AddContextExtractor(fn ContextExtractor) LoggerOption {
+	return func(ctx *loggerContext) {
+		if fn == nil {
+			ctx.addError(fmt.Errorf("%w: AddContextExtractor: extractor is nil", ErrInvalidOption))
+			return
+		}
+		ctx.extractors = append(ctx.extractors, fn)
+	}
+}
+
+// loggerKey is the context key under which NewContext stores a Logger.
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx that carries the logger.
+// Use FromContext to retrieve it, e.g. in a handler further down the call chain.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - l: The logger to store
+//
+// Returns:
+//   - context.Context: The context carrying the logger
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx with NewContext, or the global
+// logger if ctx carries none. The returned logger is bound to ctx with
+// WithContext, so the context extractors also see ctx for records logged with
+// methods without a context parameter, such as Info.
+//
+// Parameters:
+//   - ctx: The context carrying the logger
+//
+// Returns:
+//   - *Logger: The logger bound to ctx, or nil if ctx carries no logger and the global logger has not been initialized
+func FromContext(ctx context.Context) *Logger {
+	l, ok := ctx.Value(loggerKey{}).(*Logger)
+	if !ok || l == nil {
+		l = L()
+	}
+	return l.WithContext(ctx)
+}
+
+// levelKey is the context key under which WithLevel stores a level.
+type levelKey struct{}
+
+// WithLevel returns a copy of ctx that overrides the level of the records
+// logged with it, so a single request can be logged at TRACE while all others
+// stay at INFO. The override replaces the logger-wide level and the levels of
+// named loggers for the *Context methods, such as DebugContext and
+// TraceContext, and for loggers bound to ctx with WithContext. Levels set for
+// single outputs with OutputLevel still apply.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - level: The minimum level for records logged with the context
+//
+// Returns:
+//   - context.Context: The context carrying the level
+func WithLevel(ctx context.Context, level slog.Level) context.Context {
+	return context.WithValue(ctx, levelKey{}, level)
+}
+
+// LevelFromContext returns the level stored with WithLevel.
+//
+// Parameters:
+//   - ctx: The context carrying the level
+//
+// Returns:
+//   - slog.Level: The level
+//   - bool: True if ctx carries a level
+func LevelFromContext(ctx context.Context) (slog.Level, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	level, ok := ctx.Value(levelKey{}).(slog.Level)
+	return level, ok
+}
+
+// minLevel returns the minimum level for a record, which is the level stored
+// in its context or otherwise the given level.
+//
+// Parameters:
+//   - ctx: The context of the record
+//   - level: The level that applies without an override
+//
+// Returns:
+//   - slog.Level: The minimum level
+func minLevel(ctx context.Context, level slog.Leveler) slog.Level {
+	if l, ok := LevelFromContext(ctx); ok {
+		return l
+	}
+	return level.Level()
+}
+
+// extractAttrs runs the extractors on a context.
+//
+// Parameters:
+//   - ctx: The context of the record
+//   - extractors: The extractors to run
+//
+// Returns:
+//   - []slog.Attr: The extracted attributes in the order of the extractors
+func extractAttrs(ctx context.Context, extractors []ContextExtractor) []slog.Attr {
+	if ctx == nil || len(extractors) == 0 {
+		return nil
+	}
+
+	var attrs []slog.Attr
+	for _, extract := range extractors {
+		attrs = append(attrs, extract(ctx)...)
+	}
+	return attrs
+}
+
+// contextExtracting is implemented by the handlers of this package, which
+// write the extracted attributes at the top level of a record.
+type contextExtracting interface {
+	withExtractors(extractors []ContextExtractor) slog.Handler
+}
+
+// withExtractors derives a handler that adds the attributes extracted from the
+// context of every record. Handlers of other packages are wrapped in a handler
+// that adds the extracted attributes to the record.
+//
+// Parameters:
+//   - h: The handler to derive from
+//   - extractors: The extractors to run for every record
+//
+// Returns:
+//   - slog.Handler: The derived handler, or h itself if there are no extractors
+func withExtractors(h slog.Handler, extractors []ContextExtractor) slog.Handler {
+	if len(extractors) == 0 {
+		return h
+	}
+	if e, ok := h.(contextExtracting); ok {
+		return e.withExtractors(extractors)
+	}
+	return &extractingHandler{next: h, extractors: extractors}
+}
+
+// extractingHandler adds the extracted attributes to the records passed to a
+// handler that does not support context extractors.
+type extractingHandler struct {
+	next       slog.Handler
+	extractors []ContextExtractor
+}
+
+// Enabled implements slog.Handler interface.
+// It reports whether the wrapped handler processes the level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *extractingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler interface.
+// It passes a copy of the record with the extracted attributes to the wrapped handler.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Any error returned by the wrapped handler
+func (h *extractingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := extractAttrs(ctx, h.extractors); len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a handler with the same extractors that wraps next with the attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *extractingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &extractingHandler{next: h.next.WithAttrs(attrs), extractors: h.extractors}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler with the same extractors that wraps next with the group.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *extractingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &extractingHandler{next: h.next.WithGroup(name), extractors: h.extractors}
+}
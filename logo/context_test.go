@@ -0,0 +1,257 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the context extractors, the helpers storing a
+// Logger in a context and the per-request level override.
+package logo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// requestIDExtractor extracts the request ID stored under contextKey("request_id").
+//
+// Parameters:
+//   - ctx: The context of the record
+//
+// Returns:
+//   - []slog.Attr: The request_id attribute, or nil if the context holds no request ID
+func requestIDExtractor(ctx context.Context) []slog.Attr {
+	if id, ok := ctx.Value(contextKey("request_id")).(string); ok {
+		return []slog.Attr{slog.String("request_id", id)}
+	}
+	return nil
+}
+
+// TestAddContextExtractor tests that the attributes extracted from the context
+// are written at the top level by the text, JSON and record channel outputs.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAddContextExtractor(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text, jsonBuf bytes.Buffer
+	entries := make(chan Entry, 10)
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddWriterOutput(&jsonBuf, OutputFormat(FormatJSON)),
+		AddRecordChannelOutput(entries),
+		AddContextExtractor(requestIDExtractor),
+	)
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-1")
+	testLogger.WithGroup("http").InfoContext(ctx, "handled", "status", 200)
+
+	if got := text.String(); !strings.Contains(got, " request_id=req-1") || !strings.Contains(got, "http.status=200") {
+		t.Errorf("Text output = %q, want a top-level request_id", got)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	if entry["request_id"] != "req-1" {
+		t.Errorf("JSON request_id = %v, want %q", entry["request_id"], "req-1")
+	}
+
+	if e := <-entries; e.Attrs["request_id"] != "req-1" {
+		t.Errorf("Entry attributes = %v, want request_id=req-1", e.Attrs)
+	}
+
+	// Records without the value in their context are unchanged
+	text.Reset()
+	testLogger.Info("background")
+	if strings.Contains(text.String(), "request_id") {
+		t.Errorf("Text output = %q, want no request_id", text.String())
+	}
+
+	// Attributes added with With take precedence
+	text.Reset()
+	testLogger.With("request_id", "override").InfoContext(ctx, "override")
+	if got := text.String(); !strings.Contains(got, "request_id=override") {
+		t.Errorf("Text output = %q, want request_id=override", got)
+	}
+
+	if _, err := NewLoggerE(AddContextExtractor(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("AddContextExtractor(nil) error = %v, want ErrInvalidOption", err)
+	}
+}
+
+// TestAddContextExtractor_CustomHandler tests that the extracted attributes
+// are added to the records passed to a custom handler.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAddContextExtractor_CustomHandler(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := NewLogger(
+		UseCustomHandler(slog.NewTextHandler(&buf, nil)),
+		AddContextExtractor(requestIDExtractor),
+	)
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-2")
+	testLogger.InfoContext(ctx, "handled")
+
+	if got := buf.String(); !strings.Contains(got, "request_id=req-2") {
+		t.Errorf("Output = %q, want request_id=req-2", got)
+	}
+}
+
+// TestNewContext tests storing a logger in a context and retrieving it.
+// It verifies that the retrieved logger is bound to the context and that the
+// global logger is used for contexts without a logger.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNewContext(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		AddContextExtractor(requestIDExtractor),
+	)
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-3")
+	ctx = NewContext(ctx, testLogger.With("component", "api"))
+
+	FromContext(ctx).Info("from context")
+	if got := w.String(); !strings.Contains(got, "component=api") || !strings.Contains(got, "request_id=req-3") {
+		t.Errorf("Output = %q, want the stored logger bound to the context", got)
+	}
+
+	Init(DisableConsole())
+	if got := FromContext(context.Background()); got == nil || got.config() != L().config() {
+		t.Error("FromContext() without a stored logger should return the global logger")
+	}
+}
+
+// TestWithLevel tests the per-request level override. It verifies that the
+// override replaces the logger-wide and named levels for the *Context methods
+// and for bound loggers, and that output levels still apply.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestWithLevel(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	all, info := newOpenWriter(), newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(all),
+		AddWriterOutput(info, OutputLevel(slog.LevelInfo)),
+		SetLevelFor("db", slog.LevelWarn),
+		AddSource(),
+	)
+	db := testLogger.Named("db")
+
+	ctx := WithLevel(context.Background(), LevelTrace)
+	testLogger.Debug("without override")
+	testLogger.DebugContext(ctx, "debug with override")
+	testLogger.TraceContext(ctx, "trace with override")
+	traceSource := fmt.Sprintf("source=%s:%d", CallerFile(), CallerLine()-1)
+	db.WithContext(ctx).Debug("named with override")
+
+	got := all.String()
+	if strings.Contains(got, "without override") {
+		t.Errorf("Record without override was written: %q", got)
+	}
+	for _, msg := range []string{"debug with override", "trace with override", "named with override"} {
+		if !strings.Contains(got, msg) {
+			t.Errorf("Output = %q, want %q", got, msg)
+		}
+	}
+	if !strings.Contains(got, "level=TRACE") || !strings.Contains(got, traceSource) {
+		t.Errorf("TraceContext() record = %q, want TRACE with the caller's source", got)
+	}
+	if strings.Contains(info.String(), "override") {
+		t.Errorf("Output with its own level got %q, want no records", info.String())
+	}
+
+	// The override can also raise the level
+	testLogger.InfoContext(WithLevel(context.Background(), slog.LevelError), "raised")
+	if strings.Contains(all.String(), "raised") {
+		t.Error("Record below the context level was written")
+	}
+
+	if _, ok := LevelFromContext(context.Background()); ok {
+		t.Error("LevelFromContext() reported a level for a context without one")
+	}
+}
+
+// TestFatalContext tests that FatalContext logs with the context and exits.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFatalContext(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		AddSource(),
+		AddContextExtractor(requestIDExtractor),
+	)
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-4")
+	testLogger.FatalContext(ctx, "fatal with context")
+
+	if exitCode != 1 {
+		t.Errorf("FatalContext() called osExit with code %d, want 1", exitCode)
+	}
+	if got := w.String(); !strings.Contains(got, "level=FATAL") || !strings.Contains(got, "request_id=req-4") || !strings.Contains(got, "context_test.go") {
+		t.Errorf("Output = %q, want a FATAL record with the request ID and the caller's source", got)
+	}
+}
+
+// TestPanicContext tests that PanicContext logs with the context and panics.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPanicContext(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		AddSource(),
+		AddContextExtractor(requestIDExtractor),
+	)
+
+	defer func() {
+		if r := recover(); r != "panic with context" {
+			t.Errorf("PanicContext() panicked with %v, want %q", r, "panic with context")
+		}
+		if got := w.String(); !strings.Contains(got, "level=PANIC") || !strings.Contains(got, "request_id=req-5") || !strings.Contains(got, "context_test.go") {
+			t.Errorf("Output = %q, want a PANIC record with the request ID and the caller's source", got)
+		}
+	}()
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-5")
+	testLogger.PanicContext(ctx, "panic with context")
+}
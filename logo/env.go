@@ -0,0 +1,191 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the environment variable configuration which allows the
+// settings of a logger to be overridden per deployment without code changes.
+package logo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FromEnv overrides the logger settings with environment variables.
+// The variables are read when the logger is created and applied after all
+// other options, so they take precedence over the settings in code regardless
+// of where FromEnv appears in the option list. Unset variables keep the
+// settings in code. With the prefix "MYAPP_LOG" the following variables are used:
+//
+//	MYAPP_LOG_LEVEL             minimum level, e.g. "debug" or "warn"
+//	MYAPP_LOG_FORMAT            "text", "json" or "json-pretty"
+//	MYAPP_LOG_COLOR             enable or disable console colors
+//	MYAPP_LOG_SOURCE            include source file and line information
+//	MYAPP_LOG_STACKTRACES       include stack traces in fatal log entries
+//	MYAPP_LOG_STACKTRACES_AT    minimum level of log entries with a stack trace
+//	MYAPP_LOG_CONSOLE           enable or disable the console output
+//	MYAPP_LOG_FILE              path of an additional log file
+//	MYAPP_LOG_FILE_MAX_SIZE     rotation size of the log file in megabytes
+//	MYAPP_LOG_FILE_MAX_BACKUPS  number of rotated log files to keep
+//	MYAPP_LOG_FILE_MAX_AGE      number of days to keep rotated log files
+//	MYAPP_LOG_FILE_COMPRESS     compress rotated log files
+//
+// Boolean variables accept the values understood by strconv.ParseBool.
+// MYAPP_LOG_COLOR takes precedence over the standard NO_COLOR and FORCE_COLOR
+// variables, which are honored for the console output with or without FromEnv.
+//
+// Parameters:
+//   - prefix: The prefix of the variable names, without the trailing underscore
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to apply the environment
+func FromEnv(prefix string) LoggerOption {
+	return func(ctx *loggerContext) {
+		ctx.lateOpts = append(ctx.lateOpts, func(ctx *loggerContext) {
+			applyEnv(ctx, prefix, os.LookupEnv)
+		})
+	}
+}
+
+// applyEnv applies the environment variables with the given prefix to the logger context.
+// Invalid values are recorded as errors naming the variable and skipped.
+//
+// Parameters:
+//   - ctx: The logger context to configure
+//   - prefix: The prefix of the variable names
+//   - lookup: The function used to read a variable, typically os.LookupEnv
+func applyEnv(ctx *loggerContext, prefix string, lookup func(string) (string, bool)) {
+	prefix = strings.TrimSuffix(prefix, "_") + "_"
+
+	// get returns the trimmed value of a variable that is set and not empty
+	get := func(name string) (string, string, bool) {
+		key := prefix + name
+		value, ok := lookup(key)
+		value = strings.TrimSpace(value)
+		return key, value, ok && value != ""
+	}
+
+	envError := func(key, value string, err error) {
+		ctx.addError(fmt.Errorf("%w: environment %s=%q: %v", ErrInvalidOption, key, value, err))
+	}
+
+	getBool := func(name string, apply func(bool)) {
+		if key, value, ok := get(name); ok {
+			if b, err := strconv.ParseBool(value); err != nil {
+				envError(key, value, errors.New("invalid boolean"))
+			} else {
+				apply(b)
+			}
+		}
+	}
+
+	getInt := func(name string) (int, bool) {
+		key, value, ok := get(name)
+		if !ok {
+			return 0, true
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			envError(key, value, errors.New("invalid integer"))
+			return 0, false
+		}
+		return n, true
+	}
+
+	if key, value, ok := get("LEVEL"); ok {
+		if level, err := ParseLevel(value); err != nil {
+			envError(key, value, err)
+		} else {
+			SetLevel(level)(ctx)
+		}
+	}
+
+	if key, value, ok := get("FORMAT"); ok {
+		switch format, err := ParseFormat(value); {
+		case err != nil:
+			envError(key, value, err)
+		case format == FormatJSON:
+			UseJSON(false)(ctx)
+		case format == FormatPrettyJSON:
+			UseJSON(true)(ctx)
+		default:
+			ctx.useJSONFormat = false
+		}
+	}
+
+	getBool("COLOR", func(enabled bool) {
+		ctx.colorEnabled = enabled
+		ctx.forceColor = enabled
+		ctx.colorSet = true
+	})
+	getBool("SOURCE", func(enabled bool) { ctx.includeSource = enabled })
+	getBool("STACKTRACES", func(enabled bool) { ctx.includeStackTraces = enabled })
+	if key, value, ok := get("STACKTRACES_AT"); ok {
+		if level, err := ParseLevel(value); err != nil {
+			envError(key, value, err)
+		} else {
+			StackTracesAt(level)(ctx)
+		}
+	}
+	getBool("CONSOLE", func(enabled bool) {
+		if enabled {
+			AddConsoleOutput()(ctx)
+			return
+		}
+
+		// Remove the console output, whether configured in code or by default
+		ctx.consoleOn = false
+		outputs := ctx.outputs[:0]
+		for _, out := range ctx.outputs {
+			if !out.console {
+				outputs = append(outputs, out)
+			}
+		}
+		ctx.outputs = outputs
+	})
+
+	if _, path, ok := get("FILE"); ok {
+		maxSize, sizeOK := getInt("FILE_MAX_SIZE")
+		maxBackups, backupsOK := getInt("FILE_MAX_BACKUPS")
+		maxAge, ageOK := getInt("FILE_MAX_AGE")
+		compress := false
+		getBool("FILE_COMPRESS", func(enabled bool) { compress = enabled })
+
+		if sizeOK && backupsOK && ageOK {
+			// The file is added to the outputs, so keep the default console output if it is enabled
+			if ctx.consoleOn && len(ctx.outputs) == 0 {
+				ctx.outputs = append(ctx.outputs, newConsoleOutput())
+			}
+			AddFileOutput(path, maxSize, maxBackups, maxAge, compress)(ctx)
+		}
+	}
+}
+
+// consoleColors returns whether the console output is colored, applying the
+// standard NO_COLOR and FORCE_COLOR variables unless colors were set
+// explicitly with DisableColors, a configuration file or FromEnv.
+// A non-empty NO_COLOR disables colors; FORCE_COLOR enables them even if the
+// output is not detected as a color terminal, unless it is a false boolean.
+//
+// Parameters:
+//   - ctx: The logger context holding the color settings
+//   - lookup: The function used to read a variable, typically os.LookupEnv
+//
+// Returns:
+//   - bool: True if the theme is applied
+//   - bool: True if colors are rendered regardless of terminal detection
+func consoleColors(ctx *loggerContext, lookup func(string) (string, bool)) (bool, bool) {
+	if ctx.colorSet {
+		return ctx.colorEnabled, ctx.forceColor
+	}
+	if value, ok := lookup("NO_COLOR"); ok && value != "" {
+		return false, false
+	}
+	if value, ok := lookup("FORCE_COLOR"); ok && value != "" {
+		if force, err := strconv.ParseBool(value); err != nil || force {
+			return true, true
+		}
+	}
+	return ctx.colorEnabled, ctx.forceColor
+}
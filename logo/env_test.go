@@ -0,0 +1,212 @@
+package logo
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mapLookup returns a lookup function that reads variables from a map.
+//
+// Parameters:
+//   - env: The variables and their values
+//
+// Returns:
+//   - func(string) (string, bool): A replacement for os.LookupEnv
+func mapLookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+// TestApplyEnv tests applying environment variables to a logger context.
+// It verifies that every supported variable overrides the matching setting.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestApplyEnv(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	logFile := filepath.Join(t.TempDir(), "env.log")
+	ctx := &loggerContext{consoleOn: true, colorEnabled: true, logLevel: slog.LevelInfo}
+	applyEnv(ctx, "MYAPP_LOG_", mapLookup(map[string]string{
+		"MYAPP_LOG_LEVEL":            "debug",
+		"MYAPP_LOG_FORMAT":           "json-pretty",
+		"MYAPP_LOG_COLOR":            "false",
+		"MYAPP_LOG_SOURCE":           "1",
+		"MYAPP_LOG_STACKTRACES":      "true",
+		"MYAPP_LOG_FILE":             logFile,
+		"MYAPP_LOG_FILE_MAX_SIZE":    "20",
+		"MYAPP_LOG_FILE_MAX_BACKUPS": "2",
+		"MYAPP_LOG_FILE_MAX_AGE":     "7",
+		"MYAPP_LOG_FILE_COMPRESS":    "true",
+		"OTHER_LEVEL":                "error",
+	}))
+
+	if err := ctx.err(); err != nil {
+		t.Fatalf("applyEnv() recorded errors: %v", err)
+	}
+
+	if ctx.logLevel != slog.LevelDebug || !ctx.useJSONFormat || !ctx.jsonPretty || ctx.colorEnabled || !ctx.includeSource || !ctx.includeStackTraces {
+		t.Errorf("Settings not applied: %+v", ctx)
+	}
+
+	// The file is added next to the default console output
+	if len(ctx.outputs) != 2 || !ctx.outputs[0].console || len(ctx.fileWriters) != 1 {
+		t.Fatalf("Expected console and file output, got %d outputs", len(ctx.outputs))
+	}
+
+	fw := ctx.fileWriters[0]
+	if fw.Filename != logFile || fw.MaxSize != 20 || fw.MaxBackups != 2 || fw.MaxAge != 7 || !fw.Compress {
+		t.Errorf("File output settings not applied: %+v", fw)
+	}
+}
+
+// TestApplyEnv_Errors tests the errors of invalid environment variables.
+// It verifies that every error names the variable and the invalid setting is skipped.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestApplyEnv_Errors(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ctx := &loggerContext{logLevel: slog.LevelInfo}
+	applyEnv(ctx, "APP", mapLookup(map[string]string{
+		"APP_LEVEL":         "loud",
+		"APP_SOURCE":        "sometimes",
+		"APP_FILE":          filepath.Join(t.TempDir(), "app.log"),
+		"APP_FILE_MAX_SIZE": "big",
+	}))
+
+	err := ctx.err()
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("applyEnv() error = %v, want ErrInvalidOption", err)
+	}
+
+	for _, want := range []string{`APP_LEVEL="loud"`, `APP_SOURCE="sometimes": invalid boolean`, `APP_FILE_MAX_SIZE="big": invalid integer`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Error %q does not contain %q", err, want)
+		}
+	}
+
+	if ctx.logLevel != slog.LevelInfo || len(ctx.outputs) != 0 {
+		t.Error("Invalid variables should not change the settings")
+	}
+}
+
+// TestConsoleColors tests the standard NO_COLOR and FORCE_COLOR variables.
+// It verifies their effect and that colors set explicitly, including by the
+// prefixed variable, take precedence.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleColors(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       map[string]string
+		opts      []LoggerOption
+		wantColor bool
+		wantForce bool
+	}{
+		{"no variables", map[string]string{}, nil, true, false},
+		{"NO_COLOR", map[string]string{"NO_COLOR": "1"}, nil, false, false},
+		{"empty NO_COLOR", map[string]string{"NO_COLOR": ""}, nil, true, false},
+		{"FORCE_COLOR", map[string]string{"FORCE_COLOR": "1"}, nil, true, true},
+		{"FORCE_COLOR=0", map[string]string{"FORCE_COLOR": "0"}, nil, true, false},
+		{"prefixed wins", map[string]string{"NO_COLOR": "1", "APP_COLOR": "true"}, nil, true, true},
+		{"DisableColors wins", map[string]string{"FORCE_COLOR": "1"}, []LoggerOption{DisableColors()}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &loggerContext{colorEnabled: true}
+			for _, opt := range tt.opts {
+				opt(ctx)
+			}
+			applyEnv(ctx, "APP", mapLookup(tt.env))
+
+			colors, force := consoleColors(ctx, mapLookup(tt.env))
+			if colors != tt.wantColor || force != tt.wantForce {
+				t.Errorf("consoleColors() = %v, %v, want %v, %v", colors, force, tt.wantColor, tt.wantForce)
+			}
+		})
+	}
+}
+
+// TestConsoleColors_WithoutFromEnv tests that NO_COLOR and FORCE_COLOR apply
+// to the console output of loggers that do not use FromEnv.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleColors_WithoutFromEnv(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+	NewLogger(SetConsoleOutput(&buf)).Info("forced")
+	if !strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("FORCE_COLOR should color the output: %q", buf.String())
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	buf.Reset()
+	NewLogger(SetConsoleOutput(&buf)).Info("plain")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("NO_COLOR should disable colors: %q", buf.String())
+	}
+}
+
+// TestFromEnv tests the FromEnv option.
+// It verifies that the environment overrides options given after it and that
+// FORCE_COLOR renders colors to a writer that is not a terminal.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFromEnv(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	t.Setenv("MYAPP_LOG_LEVEL", "warn")
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+	l, err := NewLoggerE(
+		FromEnv("MYAPP_LOG"),
+		SetLevel(slog.LevelDebug),
+		SetConsoleOutput(&buf),
+	)
+	if err != nil {
+		t.Fatalf("NewLoggerE() error = %v", err)
+	}
+
+	l.Info("filtered by environment level")
+	l.Warn("shown")
+
+	output := buf.String()
+	if strings.Contains(output, "filtered by environment level") {
+		t.Errorf("Environment level was not applied: %q", output)
+	}
+
+	if !strings.Contains(output, "\x1b[") || !strings.Contains(stripAnsi(output), "msg=shown") {
+		t.Errorf("FORCE_COLOR should color the output: %q", output)
+	}
+
+	// Disabling the console removes the console output
+	t.Setenv("MYAPP_LOG_CONSOLE", "false")
+	buf.Reset()
+	l = NewLogger(SetConsoleOutput(&buf), FromEnv("MYAPP_LOG"))
+	l.Warn("not shown")
+	if buf.Len() != 0 {
+		t.Errorf("MYAPP_LOG_CONSOLE=false should disable the console output: %q", buf.String())
+	}
+}
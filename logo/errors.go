@@ -0,0 +1,154 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the rendering of error attributes, which writes the
+// message, the Go type, the fields and the wrapped errors of an error instead
+// of only its message.
+package logo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// ErrorKey is the key of the attribute created by Err.
+const ErrorKey = "error"
+
+// maxErrorDepth is the number of nested wrapped errors that are rendered.
+const maxErrorDepth = 16
+
+// Err returns an attribute holding an error under the "error" key.
+// The text and JSON outputs render an error attribute, whether created by Err
+// or passed as any other attribute value, as a group with these keys:
+//
+//	msg     the message returned by Error()
+//	type    the Go type, e.g. *fs.PathError
+//	fields  the value returned by LogValue() if the error implements slog.LogValuer
+//	cause   the error returned by Unwrap() error, rendered the same way
+//	causes  the errors returned by Unwrap() []error, such as by errors.Join
+//
+// JSON outputs write causes as an array, text outputs as groups named by their index.
+//
+// Parameters:
+//   - err: The error to log
+//
+// Returns:
+//   - slog.Attr: The error attribute
+func Err(err error) slog.Attr {
+	return slog.Any(ErrorKey, err)
+}
+
+// errorOf returns the error held by an attribute value before it is resolved,
+// so errors implementing slog.LogValuer are still recognized.
+//
+// Parameters:
+//   - v: The attribute value
+//
+// Returns:
+//   - error: The error
+//   - bool: True if v holds a non-nil error
+func errorOf(v slog.Value) (error, bool) {
+	var err error
+	switch v.Kind() {
+	case slog.KindAny:
+		err, _ = v.Any().(error)
+	case slog.KindLogValuer:
+		err, _ = v.LogValuer().(error)
+	}
+	return err, err != nil
+}
+
+// resolveAttr resolves the value of an attribute unless it holds an error,
+// which is kept as a plain value so ReplaceAttr and the expansion see the error
+// itself rather than the value of its LogValue method.
+//
+// Parameters:
+//   - a: The attribute to resolve in place
+//
+// Returns:
+//   - error: The error held by the attribute
+//   - bool: True if the attribute holds a non-nil error
+func resolveAttr(a *slog.Attr) (error, bool) {
+	if err, ok := errorOf(a.Value); ok {
+		a.Value = slog.AnyValue(err)
+		return err, true
+	}
+	a.Value = a.Value.Resolve()
+	return nil, false
+}
+
+// errorFields returns the structured fields an error exposes through slog.LogValuer.
+//
+// Parameters:
+//   - err: The error
+//
+// Returns:
+//   - slog.Value: The resolved value returned by LogValue
+//   - bool: True if the error implements slog.LogValuer
+func errorFields(err error) (slog.Value, bool) {
+	valuer, ok := err.(slog.LogValuer)
+	if !ok {
+		return slog.Value{}, false
+	}
+	return valuer.LogValue().Resolve(), true
+}
+
+// errorCauses returns the errors wrapped by an error.
+//
+// Parameters:
+//   - err: The error
+//
+// Returns:
+//   - []error: The wrapped errors without nil values
+//   - bool: True if the error wraps several errors, like the ones of errors.Join
+func errorCauses(err error) ([]error, bool) {
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		causes := make([]error, 0, len(u.Unwrap()))
+		for _, cause := range u.Unwrap() {
+			if cause != nil {
+				causes = append(causes, cause)
+			}
+		}
+		return causes, true
+	default:
+		if cause := errors.Unwrap(err); cause != nil {
+			return []error{cause}, false
+		}
+		return nil, false
+	}
+}
+
+// errorValue converts an error into the group rendered by the text outputs.
+//
+// Parameters:
+//   - err: The error
+//   - depth: The number of wrapped errors that may still be rendered
+//
+// Returns:
+//   - slog.Value: The group describing the error
+func errorValue(err error, depth int) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("msg", err.Error()),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+	if fields, ok := errorFields(err); ok {
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: fields})
+	}
+
+	if depth > 0 {
+		causes, joined := errorCauses(err)
+		if joined {
+			group := make([]slog.Attr, len(causes))
+			for i, cause := range causes {
+				group[i] = slog.Attr{Key: strconv.Itoa(i), Value: errorValue(cause, depth-1)}
+			}
+			attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(group...)})
+		} else if len(causes) == 1 {
+			attrs = append(attrs, slog.Attr{Key: "cause", Value: errorValue(causes[0], depth-1)})
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,167 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the rendering of error attributes.
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// codeError is an error exposing structured fields through slog.LogValuer.
+type codeError struct {
+	code int
+}
+
+// Error returns the error message.
+//
+// Returns:
+//   - string: The error message
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+// LogValue returns the fields of the error.
+//
+// Returns:
+//   - slog.Value: A group with the error code
+func (e *codeError) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("code", e.code))
+}
+
+// TestErr tests that Err creates an attribute under the error key.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestErr(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	err := errors.New("boom")
+	attr := Err(err)
+	if attr.Key != ErrorKey || attr.Value.Any() != err {
+		t.Errorf("Err() = %v, want %s=%v", attr, ErrorKey, err)
+	}
+}
+
+// TestErrorRendering_Text tests that the text output writes the message, type,
+// fields and wrapped errors of an error attribute.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestErrorRendering_Text(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(&buf))
+
+	err := fmt.Errorf("saving: %w", errors.Join(&codeError{code: 42}, errors.New("disk full")))
+	testLogger.WithGroup("req").Error("Request failed", Err(err))
+
+	got := buf.String()
+	for _, want := range []string{
+		"req.error.msg=saving: code 42\ndisk full ",
+		"req.error.type=*fmt.wrapError",
+		"req.error.cause.type=*errors.joinError",
+		"req.error.cause.causes.0.msg=code 42 ",
+		"req.error.cause.causes.0.type=*logo.codeError",
+		"req.error.cause.causes.0.fields.code=42",
+		"req.error.cause.causes.1.msg=disk full ",
+		"req.error.cause.causes.1.type=*errors.errorString",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Text output = %q, want %q", got, want)
+		}
+	}
+}
+
+// TestErrorRendering_JSON tests that the JSON output writes an error attribute
+// as an object, with the errors of errors.Join as an array.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestErrorRendering_JSON(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(&buf, OutputFormat(FormatJSON)))
+
+	testLogger.Error("Request failed", "err", fmt.Errorf("saving: %w", errors.Join(&codeError{code: 42}, errors.New("disk full"))))
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", buf.String(), err)
+	}
+
+	want := map[string]any{
+		"msg":  "saving: code 42\ndisk full",
+		"type": "*fmt.wrapError",
+		"cause": map[string]any{
+			"msg":  "code 42\ndisk full",
+			"type": "*errors.joinError",
+			"causes": []any{
+				map[string]any{"msg": "code 42", "type": "*logo.codeError", "fields": map[string]any{"code": float64(42)}},
+				map[string]any{"msg": "disk full", "type": "*errors.errorString"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(entry["err"], want) {
+		t.Errorf("JSON error = %#v, want %#v", entry["err"], want)
+	}
+}
+
+// TestErrorRendering_ReplaceAttr tests that ReplaceAttr receives error
+// attributes as a whole, so they can be replaced before they are expanded.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestErrorRendering_ReplaceAttr(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			err, ok := a.Value.Any().(error)
+			switch {
+			case !ok:
+				return a
+			case a.Key == "secret":
+				return slog.String(a.Key, "[redacted]")
+			default:
+				return slog.Any(a.Key, fmt.Errorf("replaced: %w", err))
+			}
+		},
+	}
+
+	var text, jsonBuf bytes.Buffer
+	testLogger := slog.New(NewFanoutHandler(slog.LevelInfo,
+		NewCustomTextHandler(&text, opts),
+		NewJSONHandler(&jsonBuf, opts, false),
+	))
+	testLogger.Error("failed", "secret", &codeError{code: 7}, "err", errors.New("boom"))
+
+	got := text.String()
+	for _, want := range []string{" secret=[redacted]", " err.msg=replaced: boom ", " err.cause.msg=boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Text output = %q, want %q", got, want)
+		}
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	errObject, _ := entry["err"].(map[string]any)
+	if entry["secret"] != "[redacted]" || errObject["msg"] != "replaced: boom" {
+		t.Errorf("JSON output = %v, want the replaced errors", entry)
+	}
+}
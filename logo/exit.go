@@ -0,0 +1,145 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the shutdown sequence run by Fatal, the flush run by
+// Panic, the exit hooks and the configurable exit code.
+package logo
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// defaultExitTimeout is the time exit hooks may take when no timeout was configured.
+const defaultExitTimeout = 5 * time.Second
+
+// SetExitCode sets the status code Fatal exits the program with.
+// The default exit code is 1.
+//
+// Parameters:
+//   - code: The exit code passed to os.Exit by Fatal
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the exit code
+func SetExitCode(code int) LoggerOption {
+	return func(ctx *loggerContext) {
+		ctx.exitCode = code
+	}
+}
+
+// AddExitHook registers a function that Fatal runs after the logger has been
+// closed and Panic runs after it has been flushed. Hooks run one after another
+// in the order they were added, which makes them suitable for flushing metrics
+// or notifying other systems.
+//
+// Parameters:
+//   - hook: The function to run before the program terminates
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to add the exit hook
+func AddExitHook(hook func()) LoggerOption {
+	return func(ctx *loggerContext) {
+		ctx.exitHooks = append(ctx.exitHooks, hook)
+	}
+}
+
+// SetExitTimeout limits how long Fatal and Panic wait for the exit hooks.
+// Hooks still running when the timeout expires are abandoned. The default
+// timeout is 5 seconds.
+//
+// Parameters:
+//   - timeout: The maximum total time for all exit hooks
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the exit timeout
+func SetExitTimeout(timeout time.Duration) LoggerOption {
+	return func(ctx *loggerContext) {
+		if timeout < 0 {
+			ctx.addError(fmt.Errorf("%w: SetExitTimeout: negative timeout %v", ErrInvalidOption, timeout))
+			return
+		}
+		ctx.exitTimeout = timeout
+	}
+}
+
+// shutdown closes the logger and runs its exit hooks.
+// It is called by Fatal before the program exits.
+func (l *Logger) shutdown() {
+	// Write queued records and close files so nothing is lost on exit
+	if err := l.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error closing logger: %v\n", err)
+	}
+	l.runExitHooks()
+}
+
+// flushBeforePanic writes queued records and runs the exit hooks of the logger.
+// It is called by Panic, which leaves the logger open, since the panic may be
+// recovered and the logger used afterwards.
+func (l *Logger) flushBeforePanic() {
+	if err := l.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error flushing logger: %v\n", err)
+	}
+	if ctx := l.config(); ctx != nil {
+		if err := ctx.sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing logger outputs: %v\n", err)
+		}
+	}
+	l.runExitHooks()
+}
+
+// runExitHooks runs the exit hooks of the logger, waiting at most for the
+// configured exit timeout.
+func (l *Logger) runExitHooks() {
+	ctx := l.config()
+	if ctx == nil || len(ctx.exitHooks) == 0 {
+		return
+	}
+
+	timeout := ctx.exitTimeout
+	if timeout <= 0 {
+		timeout = defaultExitTimeout
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, hook := range ctx.exitHooks {
+			runExitHook(hook)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		fmt.Fprintf(os.Stderr, "Exit hooks did not finish within %v\n", timeout)
+	}
+}
+
+// exitCode returns the status code Fatal exits the program with.
+//
+// Returns:
+//   - int: The configured exit code, or 1 for loggers without configuration
+func (l *Logger) exitCode() int {
+	ctx := l.config()
+	if ctx == nil {
+		return 1
+	}
+	return ctx.exitCode
+}
+
+// runExitHook runs a single exit hook, recovering from a panic so that the
+// remaining hooks still run.
+//
+// Parameters:
+//   - hook: The exit hook to run
+func runExitHook(hook func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "Exit hook panicked: %v\n", r)
+		}
+	}()
+	hook()
+}
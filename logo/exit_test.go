@@ -0,0 +1,190 @@
+package logo
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFatal_ExitHooks tests the shutdown sequence of Fatal.
+// It verifies that the logger is closed before the exit hooks run, that hooks
+// run in order, and that the configured exit code is used.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFatal_ExitHooks(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+
+	var exitCode int
+	var calls []string
+	osExit = func(code int) {
+		exitCode = code
+		calls = append(calls, "exit")
+	}
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	close(w.gate)
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		EnableAsync(10, Block),
+		SetExitCode(3),
+		AddExitHook(func() {
+			// The async queue must have been drained by Close before the hooks run
+			if !strings.Contains(w.String(), "msg=shutting down") {
+				t.Error("Exit hook ran before the fatal record was written")
+			}
+			calls = append(calls, "first")
+		}),
+		AddExitHook(func() { panic("broken hook") }),
+		AddExitHook(func() { calls = append(calls, "second") }),
+	)
+
+	testLogger.Fatal("shutting down")
+
+	if exitCode != 3 {
+		t.Errorf("Fatal() called osExit with code %d, want 3", exitCode)
+	}
+
+	if strings.Join(calls, ",") != "first,second,exit" {
+		t.Errorf("Shutdown order = %v, want [first second exit]", calls)
+	}
+}
+
+// TestFatal_ExitHookTimeout tests that Fatal does not wait longer than the exit timeout.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFatal_ExitHookTimeout(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+
+	exited := false
+	osExit = func(code int) {
+		exited = true
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	testLogger := NewLogger(
+		DisableConsole(),
+		SetExitTimeout(20*time.Millisecond),
+		AddExitHook(func() { <-release }),
+	)
+
+	start := time.Now()
+	testLogger.Fatal("hook hangs")
+
+	if !exited {
+		t.Error("Fatal() did not exit after the exit timeout")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Fatal() waited %v for a hanging hook", elapsed)
+	}
+}
+
+// TestPanic tests the Panic logging method.
+// It verifies that the record is logged at PANIC level, the exit hooks run,
+// and the method panics with the message.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPanic(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+	osExit = func(code int) {
+		t.Error("Panic() must not exit the program")
+	}
+
+	var buf bytes.Buffer
+	hookRan := false
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&buf),
+		AddSource(),
+		AddExitHook(func() { hookRan = true }),
+	)
+
+	defer func() {
+		r := recover()
+		if r != "invariant violated" {
+			t.Errorf("Panic() panicked with %v, want %q", r, "invariant violated")
+		}
+
+		if !strings.Contains(buf.String(), "level=PANIC msg=invariant violated") {
+			t.Errorf("Panic message not logged: %q", buf.String())
+		}
+
+		if !strings.Contains(buf.String(), "exit_test.go:") {
+			t.Errorf("Panic record should point to the caller: %q", buf.String())
+		}
+
+		if !hookRan {
+			t.Error("Panic() did not run the exit hooks")
+		}
+	}()
+
+	testLogger.Panic("invariant violated", "id", 7)
+}
+
+// TestPanic_LoggerKeepsWorking tests that Panic flushes the logger without
+// closing it, so a logger whose panic was recovered keeps writing records.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPanic_LoggerKeepsWorking(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	close(w.gate)
+	closed := false
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		EnableAsync(10, Block),
+		AddExitHook(func() {
+			// The async queue must have been flushed before the hooks run
+			if !strings.Contains(w.String(), "msg=recoverable") {
+				t.Error("Exit hook ran before the panic record was written")
+			}
+		}),
+		func(ctx *loggerContext) {
+			ctx.closers = append(ctx.closers, func() error {
+				closed = true
+				return nil
+			})
+		},
+	)
+	defer testLogger.Close()
+
+	func() {
+		defer func() { _ = recover() }()
+		testLogger.Panic("recoverable")
+	}()
+
+	if closed {
+		t.Error("Panic() closed the logger")
+	}
+
+	testLogger.Info("after recovery")
+	if err := testLogger.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !strings.Contains(w.String(), "msg=after recovery") {
+		t.Errorf("Output = %q, want the record logged after the recovered panic", w.String())
+	}
+}
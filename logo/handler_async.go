@@ -0,0 +1,258 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the asynchronous handler implementation which queues log
+// records and writes them on a background goroutine.
+package logo
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// AsyncHandler is a slog.Handler that queues records and hands them to the
+// wrapped handler on a background goroutine, so slow outputs do not stall the
+// goroutines that log. Records are processed in the order they were queued.
+// What happens when the queue is full is selected with WithOverflowPolicy.
+//
+// Errors returned by the wrapped handler cannot be reported to the caller and
+// are discarded. Handlers derived through WithAttrs and WithGroup share the
+// queue of the handler they were derived from.
+type AsyncHandler struct {
+	next  slog.Handler
+	queue *asyncQueue
+}
+
+// asyncRecord is a queued record together with the handler that must process it.
+type asyncRecord struct {
+	ctx     context.Context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// asyncQueue is the queue and worker shared by an AsyncHandler and the
+// handlers derived from it.
+type asyncQueue struct {
+	sender  *channelSender[asyncRecord]
+	flushes chan chan struct{}
+	done    chan struct{}
+
+	// mu guards closed against records being queued while the queue closes
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncHandler creates a handler that processes records with next on a
+// background goroutine. The goroutine runs until Close is called.
+//
+// Parameters:
+//   - next: The handler that writes the records
+//   - queueSize: The number of records that can be queued
+//   - opts: Options selecting the overflow policy for a full queue
+//
+// Returns:
+//   - *AsyncHandler: An asynchronous handler wrapping next
+func NewAsyncHandler(next slog.Handler, queueSize int, opts ...ChannelOption) *AsyncHandler {
+	return &AsyncHandler{
+		next:  next,
+		queue: newAsyncQueue(queueSize, opts...),
+	}
+}
+
+// withLeveler returns a handler that shares the queue and wraps next with a
+// different logger-wide level.
+//
+// Parameters:
+//   - wrap: Returns the new level given the current one
+//
+// Returns:
+//   - slog.Handler: A new handler sharing the queue
+func (h *AsyncHandler) withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	return &AsyncHandler{next: withLeveler(h.next, wrap), queue: h.queue}
+}
+
+// newAsyncQueue creates a queue and starts its background goroutine.
+//
+// Parameters:
+//   - queueSize: The number of records that can be queued
+//   - opts: Options selecting the overflow policy for a full queue
+//
+// Returns:
+//   - *asyncQueue: The running queue
+func newAsyncQueue(queueSize int, opts ...ChannelOption) *asyncQueue {
+	q := &asyncQueue{
+		sender:  newChannelSender(make(chan asyncRecord, queueSize), opts...),
+		flushes: make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Enabled implements slog.Handler interface.
+// It reports whether the wrapped handler is enabled for the level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *AsyncHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler interface.
+// It queues a copy of the record for the background goroutine. After Close,
+// records are handled synchronously so that they are not lost.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Nil for queued records, or the error of the wrapped handler after Close
+func (h *AsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.queue.mu.RLock()
+	if h.queue.closed {
+		h.queue.mu.RUnlock()
+		return h.next.Handle(ctx, r)
+	}
+
+	h.queue.sender.send(asyncRecord{ctx: ctx, handler: h.next, record: r.Clone()})
+	h.queue.mu.RUnlock()
+	return nil
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a handler that shares the queue and wraps next with the attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &AsyncHandler{next: h.next.WithAttrs(attrs), queue: h.queue}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler that shares the queue and wraps next with the group.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &AsyncHandler{next: h.next.WithGroup(name), queue: h.queue}
+}
+
+// Flush waits until every record queued before the call has been handled.
+//
+// Returns:
+//   - error: Always nil; provided for symmetry with Close
+func (h *AsyncHandler) Flush() error {
+	return h.queue.flush()
+}
+
+// Close handles all queued records and stops the background goroutine.
+// Records logged after Close are handled synchronously. Calling Close more
+// than once is safe.
+//
+// Returns:
+//   - error: Always nil; provided to satisfy io.Closer
+func (h *AsyncHandler) Close() error {
+	return h.queue.close()
+}
+
+// Dropped returns the number of records dropped because the queue was full.
+//
+// Returns:
+//   - uint64: The total number of dropped records
+func (h *AsyncHandler) Dropped() uint64 {
+	return h.queue.sender.Dropped()
+}
+
+// flush waits until every record queued before the call has been handled.
+//
+// Returns:
+//   - error: Always nil
+func (q *asyncQueue) flush() error {
+	reply := make(chan struct{})
+	select {
+	case q.flushes <- reply:
+		<-reply
+	case <-q.done:
+		// The worker has stopped and the queue is already drained
+	}
+	return nil
+}
+
+// close stops accepting records, handles the queued ones and waits for the
+// background goroutine to exit.
+//
+// Returns:
+//   - error: Always nil
+func (q *asyncQueue) close() error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.sender.ch)
+	}
+	q.mu.Unlock()
+
+	<-q.done
+	return nil
+}
+
+// run is the background goroutine that handles queued records until the queue is closed.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	for {
+		select {
+		case rec, ok := <-q.sender.ch:
+			if !ok {
+				return
+			}
+			q.handle(rec)
+		case reply := <-q.flushes:
+			open := q.drain()
+			close(reply)
+			if !open {
+				return
+			}
+		}
+	}
+}
+
+// drain handles the records currently in the queue without waiting for new ones.
+//
+// Returns:
+//   - bool: False if the queue was closed while draining
+func (q *asyncQueue) drain() bool {
+	for {
+		select {
+		case rec, ok := <-q.sender.ch:
+			if !ok {
+				return false
+			}
+			q.handle(rec)
+		default:
+			return true
+		}
+	}
+}
+
+// handle passes a queued record to the handler it was queued for.
+//
+// Parameters:
+//   - rec: The queued record
+func (q *asyncQueue) handle(rec asyncRecord) {
+	// There is no caller to report the error to
+	_ = rec.handler.Handle(rec.ctx, rec.record)
+}
@@ -0,0 +1,227 @@
+package logo
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter is an io.Writer that blocks every write until the gate is opened,
+// simulating a slow output.
+type gatedWriter struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	buf  bytes.Buffer
+}
+
+// Write waits for the gate and then records the data.
+//
+// Parameters:
+//   - p: The data to write
+//
+// Returns:
+//   - int: The number of bytes written
+//   - error: Always nil
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+// String returns everything written so far.
+//
+// Returns:
+//   - string: The written data
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// TestAsyncHandler_DoesNotBlock tests that logging through an AsyncHandler does not wait for the output.
+// It verifies that records are written once the output is available and that Flush waits for them.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAsyncHandler_DoesNotBlock(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	handler := NewAsyncHandler(NewCustomTextHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}), 10)
+	defer handler.Close()
+	testLogger := slog.New(handler)
+
+	done := make(chan struct{})
+	go func() {
+		testLogger.Info("first")
+		testLogger.With("k", "v").Info("second")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Logging blocked on a slow output")
+	}
+
+	close(w.gate)
+	if err := handler.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	output := w.String()
+	if !strings.Contains(output, "msg=first") || !strings.Contains(output, "msg=second k=v") {
+		t.Errorf("Output after Flush() = %q, want both records", output)
+	}
+
+	if strings.Index(output, "msg=first") > strings.Index(output, "msg=second") {
+		t.Errorf("Records were written out of order: %q", output)
+	}
+}
+
+// TestAsyncHandler_Close tests the Close method of AsyncHandler.
+// It verifies that Close drains the queue and that later records are handled synchronously.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAsyncHandler_Close(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	handler := NewAsyncHandler(NewCustomTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}), 100)
+
+	for i := 0; i < 50; i++ {
+		handler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "queued", 0))
+	}
+
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := strings.Count(buf.String(), "msg=queued"); got != 50 {
+		t.Errorf("Close() wrote %d records, want 50", got)
+	}
+
+	// Closing twice and flushing after close must not block
+	handler.Close()
+	handler.Flush()
+
+	buf.Reset()
+	handler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "after close", 0))
+	if !strings.Contains(buf.String(), "msg=after close") {
+		t.Errorf("Record after Close() was not written synchronously: %q", buf.String())
+	}
+}
+
+// TestAsyncHandler_DropNewest tests an AsyncHandler whose queue is full.
+// It verifies that the overflow policy is applied and drops are counted.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAsyncHandler_DropNewest(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	handler := NewAsyncHandler(NewCustomTextHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}), 1, WithOverflowPolicy(DropNewest))
+
+	// The worker takes one record and blocks in the writer, the queue holds one more
+	handler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "written", 0))
+	time.Sleep(20 * time.Millisecond)
+	handler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "queued", 0))
+	handler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "dropped", 0))
+
+	close(w.gate)
+	handler.Close()
+
+	if handler.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", handler.Dropped())
+	}
+
+	if strings.Contains(w.String(), "msg=dropped") {
+		t.Errorf("Dropped record was written: %q", w.String())
+	}
+}
+
+// TestEnableAsync tests the EnableAsync option.
+// It verifies that the logger queues records and that Flush and Close drain them.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestEnableAsync(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		EnableAsync(10, Block),
+	)
+
+	if h := testLogger.root.current().handler; h == nil {
+		t.Fatal("Logger has no handler")
+	} else if _, ok := h.(*AsyncHandler); !ok {
+		t.Fatalf("Handler is %T, want *AsyncHandler", h)
+	}
+
+	testLogger.Info("queued")
+	close(w.gate)
+
+	if err := testLogger.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if !strings.Contains(w.String(), "msg=queued") {
+		t.Errorf("Flush() did not write the queued record: %q", w.String())
+	}
+
+	// Changing the level keeps using the same queue
+	SetLoggerLevel(testLogger, slog.LevelWarn)
+	testLogger.Warn("after level change")
+
+	if err := testLogger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !strings.Contains(w.String(), "msg=after level change") {
+		t.Errorf("Close() did not write the queued record: %q", w.String())
+	}
+}
+
+// TestEnableAsync_FatalFlushes tests that Fatal writes queued records before exiting.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestEnableAsync_FatalFlushes(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+
+	var buf bytes.Buffer
+	var outputAtExit string
+	osExit = func(code int) {
+		outputAtExit = buf.String()
+	}
+
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&buf),
+		EnableAsync(10, Block),
+	)
+	defer testLogger.Close()
+
+	testLogger.Info("before fatal")
+	testLogger.Fatal("fatal message")
+
+	if !strings.Contains(outputAtExit, "msg=before fatal") || !strings.Contains(outputAtExit, "msg=fatal message") {
+		t.Errorf("Output at exit = %q, want all queued records", outputAtExit)
+	}
+}
@@ -0,0 +1,303 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the console handler implementation which renders log
+// records directly with lipgloss styles for readable terminal output.
+package logo
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// ConsoleTheme defines the styles used by ConsoleHandler for each part of a log line.
+type ConsoleTheme struct {
+	// Timestamp styles the bracketed time at the start of the line
+	Timestamp lipgloss.Style
+
+	// Levels styles the level badge and message, or the whole line if
+	// ColorLine is set, for each log level
+	Levels map[slog.Level]lipgloss.Style
+
+	// UnknownLevel is used in place of Levels for levels that are not in it
+	UnknownLevel lipgloss.Style
+
+	// Key styles attribute keys
+	Key lipgloss.Style
+
+	// Value styles attribute values
+	Value lipgloss.Style
+
+	// Source styles the source file and line value
+	Source lipgloss.Style
+
+	// ColorLine styles everything after the timestamp, attributes and stack
+	// traces included, with the style of the level. Key, Value and Source are
+	// only used if it is false.
+	ColorLine bool
+}
+
+// DefaultConsoleTheme returns the theme used by the console output unless
+// another theme is configured with SetConsoleTheme. It colors the whole line
+// by level; set ColorLine to false to style keys, values and sources separately.
+//
+// Returns:
+//   - ConsoleTheme: The default console theme
+func DefaultConsoleTheme() ConsoleTheme {
+	return ConsoleTheme{
+		Timestamp: lipgloss.NewStyle().Foreground(lipgloss.Color("241")),
+		Levels: map[slog.Level]lipgloss.Style{
+			LevelTrace:      logLevelStyles["TRACE"],
+			slog.LevelDebug: logLevelStyles["DEBUG"],
+			slog.LevelInfo:  logLevelStyles["INFO"],
+			slog.LevelWarn:  logLevelStyles["WARN"],
+			slog.LevelError: logLevelStyles["ERROR"],
+			LevelFatal:      logLevelStyles["FATAL"],
+			LevelPanic:      logLevelStyles["PANIC"],
+		},
+		UnknownLevel: lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Bold(true),
+		Key:          lipgloss.NewStyle().Foreground(lipgloss.Color("245")),
+		Value:        lipgloss.NewStyle(),
+		Source:       lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Underline(true),
+		ColorLine:    true,
+	}
+}
+
+// withRenderer returns a copy of the theme whose styles render for the given renderer,
+// so color support is detected for the actual output instead of os.Stdout.
+//
+// Parameters:
+//   - r: The renderer for the output
+//
+// Returns:
+//   - ConsoleTheme: The theme bound to the renderer
+func (t ConsoleTheme) withRenderer(r *lipgloss.Renderer) ConsoleTheme {
+	levels := make(map[slog.Level]lipgloss.Style, len(t.Levels))
+	for level, style := range t.Levels {
+		levels[level] = style.Renderer(r)
+	}
+
+	return ConsoleTheme{
+		Timestamp:    t.Timestamp.Renderer(r),
+		Levels:       levels,
+		UnknownLevel: t.UnknownLevel.Renderer(r),
+		Key:          t.Key.Renderer(r),
+		Value:        t.Value.Renderer(r),
+		Source:       t.Source.Renderer(r),
+		ColorLine:    t.ColorLine,
+	}
+}
+
+// ConsoleHandler is a slog.Handler that renders log records for a terminal.
+// It writes the record time as a dim timestamp followed by a colored level
+// badge, the message and the attributes, without re-parsing formatted text.
+type ConsoleHandler struct {
+	text   *CustomTextHandler
+	theme  ConsoleTheme
+	colors bool
+}
+
+// NewConsoleHandler creates a new console handler with the given theme.
+//
+// Parameters:
+//   - out: The io.Writer where log entries will be written
+//   - opts: Handler options including log level and attribute replacements
+//   - theme: The styles used for the different parts of a log line
+//   - colors: Whether to apply the theme; if false, plain text is written
+//
+// Returns:
+//   - slog.Handler: A handler implementation for styled console output
+func NewConsoleHandler(out io.Writer, opts *slog.HandlerOptions, theme ConsoleTheme, colors bool) slog.Handler {
+	return newConsoleHandler(out, opts, theme, colors, false)
+}
+
+// newConsoleHandler creates a new console handler, optionally forcing colors
+// even if the output is not detected as a color terminal.
+//
+// Parameters:
+//   - out: The io.Writer where log entries will be written
+//   - opts: Handler options including log level and attribute replacements
+//   - theme: The styles used for the different parts of a log line
+//   - colors: Whether to apply the theme; if false, plain text is written
+//   - force: Whether to render colors regardless of terminal detection
+//
+// Returns:
+//   - *ConsoleHandler: A handler implementation for styled console output
+func newConsoleHandler(out io.Writer, opts *slog.HandlerOptions, theme ConsoleTheme, colors, force bool) *ConsoleHandler {
+	renderer := lipgloss.NewRenderer(out)
+	if force {
+		renderer.SetColorProfile(termenv.ANSI256)
+	}
+
+	return &ConsoleHandler{
+		text:   NewCustomTextHandler(out, opts).(*CustomTextHandler),
+		theme:  theme.withRenderer(renderer),
+		colors: colors,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+// It checks if the given log level should be processed based on the configured minimum level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *ConsoleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.text.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler interface.
+// It renders the record as a single styled line.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Any error encountered during formatting or writing
+func (h *ConsoleHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs, stacks := h.text.collectAttrs(ctx, r)
+
+	// The record time is shown in brackets instead of as an attribute
+	delete(attrs, "time")
+
+	var sb strings.Builder
+	if !r.Time.IsZero() {
+		sb.WriteString("[")
+		sb.WriteString(h.style(h.theme.Timestamp, r.Time.Format("15:04:05")))
+		sb.WriteString("] ")
+	}
+
+	levelStyle, ok := h.theme.Levels[r.Level]
+	if !ok {
+		levelStyle = h.theme.UnknownLevel
+	}
+
+	if h.theme.ColorLine {
+		// The attributes are rendered as one string, so the line is colored without gaps
+		var line strings.Builder
+		for i, key := range h.text.orderedKeys(attrs) {
+			if i > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(key + "=" + attrs[key])
+		}
+
+		// Lines of multi-line values are rendered one by one, since lipgloss pads them to the same width
+		for i, part := range strings.Split(line.String(), "\n") {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(h.style(levelStyle, part))
+		}
+		sb.WriteString("\n")
+
+		level := func(s string) string { return h.style(levelStyle, s) }
+		writeStacks(&sb, stacks, level, level, level)
+	} else {
+		for i, key := range h.text.orderedKeys(attrs) {
+			if i > 0 {
+				sb.WriteString(" ")
+			}
+
+			val := attrs[key]
+			switch key {
+			case "level", "msg":
+				sb.WriteString(h.style(levelStyle, key+"="+val))
+			case "source":
+				sb.WriteString(h.style(h.theme.Key, key+"="))
+				sb.WriteString(h.style(h.theme.Source, val))
+			default:
+				sb.WriteString(h.style(h.theme.Key, key+"="))
+				sb.WriteString(h.style(h.theme.Value, val))
+			}
+		}
+
+		sb.WriteString("\n")
+
+		// Stack traces follow on indented lines
+		writeStacks(&sb, stacks,
+			func(s string) string { return h.style(h.theme.Key, s) },
+			func(s string) string { return h.style(h.theme.Value, s) },
+			func(s string) string { return h.style(h.theme.Source, s) },
+		)
+	}
+
+	// Write to output
+	_, err := h.text.out.Write([]byte(sb.String()))
+	return err
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a new handler with the given attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ConsoleHandler{
+		text:   h.text.WithAttrs(attrs).(*CustomTextHandler),
+		theme:  h.theme,
+		colors: h.colors,
+	}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler that adds the given group name to the attribute key path.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &ConsoleHandler{
+		text:   h.text.WithGroup(name).(*CustomTextHandler),
+		theme:  h.theme,
+		colors: h.colors,
+	}
+}
+
+// withExtractors returns a copy of the handler that writes the attributes
+// extracted from the context of every record.
+//
+// Parameters:
+//   - extractors: The context extractors
+//
+// Returns:
+//   - slog.Handler: A new handler that runs the extractors
+func (h *ConsoleHandler) withExtractors(extractors []ContextExtractor) slog.Handler {
+	return &ConsoleHandler{
+		text:   h.text.withExtractors(extractors).(*CustomTextHandler),
+		theme:  h.theme,
+		colors: h.colors,
+	}
+}
+
+// style renders s with the given style when colors are enabled.
+//
+// Parameters:
+//   - style: The style to apply
+//   - s: The text to render
+//
+// Returns:
+//   - string: The styled text, or s unchanged if colors are disabled
+func (h *ConsoleHandler) style(style lipgloss.Style, s string) string {
+	if !h.colors {
+		return s
+	}
+	return style.Render(s)
+}
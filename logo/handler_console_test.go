@@ -0,0 +1,205 @@
+package logo
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewConsoleHandler tests the creation of a ConsoleHandler.
+// It verifies that the handler is properly initialized with the provided parameters.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNewConsoleHandler(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}
+
+	handler := NewConsoleHandler(&buf, opts, DefaultConsoleTheme(), true)
+
+	consoleHandler, ok := handler.(*ConsoleHandler)
+	if !ok {
+		t.Fatal("Handler is not a ConsoleHandler")
+	}
+
+	if consoleHandler.text.out != &buf {
+		t.Error("Handler output writer not set correctly")
+	}
+
+	if consoleHandler.text.opts != opts {
+		t.Error("Handler options not set correctly")
+	}
+
+	if !consoleHandler.colors {
+		t.Error("Colors should be enabled when requested")
+	}
+
+	if len(consoleHandler.theme.Levels) != len(DefaultConsoleTheme().Levels) {
+		t.Error("Handler theme not set correctly")
+	}
+}
+
+// TestConsoleHandler_Handle tests the Handle method of ConsoleHandler.
+// It verifies that records are rendered with the record time and without a second timestamp.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleHandler_Handle(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	fixedTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, colors := range []bool{false, true} {
+		var buf bytes.Buffer
+		handler := NewConsoleHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}, DefaultConsoleTheme(), colors)
+
+		for _, level := range []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError, LevelFatal} {
+			buf.Reset()
+			r := slog.NewRecord(fixedTime, level, "test message", 0)
+			r.AddAttrs(slog.String("user", "john"))
+			if err := handler.Handle(context.Background(), r); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			want := "[03:04:05] level=" + levelToString(level) + " msg=test message user=john\n"
+			if got := stripAnsi(buf.String()); got != want {
+				t.Errorf("Output (colors=%v) = %q, want %q", colors, got, want)
+			}
+		}
+	}
+}
+
+// TestConsoleHandler_Handle_ColorLine tests that the default theme colors the
+// whole line with the style of the level and that turning ColorLine off styles
+// the attributes separately.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleHandler_Handle_ColorLine(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	fixedTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, colorLine := range []bool{true, false} {
+		var buf bytes.Buffer
+		theme := DefaultConsoleTheme()
+		theme.ColorLine = colorLine
+		handler := newConsoleHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}, theme, true, true)
+
+		r := slog.NewRecord(fixedTime, slog.LevelWarn, "test message", 0)
+		r.AddAttrs(slog.String("user", "john"), slog.String("note", "first\nsecond"))
+		if err := handler.Handle(context.Background(), r); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+
+		levelStyle := handler.theme.Levels[slog.LevelWarn]
+		line := levelStyle.Render("level=WARN msg=test message note=first") + "\n" + levelStyle.Render("second user=john")
+		if got := buf.String(); strings.Contains(got, line) != colorLine {
+			t.Errorf("Output (ColorLine=%v) = %q, contains the line colored by level = %v", colorLine, got, !colorLine)
+		}
+		if got := buf.String(); strings.Contains(got, handler.theme.Key.Render("user=")) == colorLine {
+			t.Errorf("Output (ColorLine=%v) = %q, contains a styled key = %v", colorLine, got, colorLine)
+		}
+	}
+}
+
+// TestConsoleHandler_Handle_NoColors tests the Handle method with colors disabled.
+// It verifies that no ANSI escape codes are written.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleHandler_Handle_NoColors(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	handler := NewConsoleHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true}, DefaultConsoleTheme(), false)
+
+	pc, _, _, _ := runtime.Caller(0)
+	r := slog.NewRecord(time.Now(), slog.LevelError, "plain", pc)
+	if err := handler.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "\x1b[") {
+		t.Errorf("Output contains ANSI color codes when colors are disabled: %q", output)
+	}
+
+	if !strings.Contains(output, "source=") || !strings.Contains(output, "handler_console_test.go:") {
+		t.Errorf("Output should contain the source information: %q", output)
+	}
+}
+
+// TestConsoleHandler_WithAttrs tests the WithAttrs and WithGroup methods of ConsoleHandler.
+// It verifies that handler attributes and groups are rendered like in CustomTextHandler.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestConsoleHandler_WithAttrs(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	handler := NewConsoleHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}, DefaultConsoleTheme(), false)
+
+	if handler.WithGroup("") != handler {
+		t.Error("WithGroup(\"\") should return the same handler")
+	}
+
+	derived := handler.WithAttrs([]slog.Attr{slog.String("service", "api")}).WithGroup("http")
+	if _, ok := derived.(*ConsoleHandler); !ok {
+		t.Fatalf("Derived handler is %T, want *ConsoleHandler", derived)
+	}
+
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "request", 0)
+	r.AddAttrs(slog.String("method", "GET"))
+	if err := derived.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	want := "level=INFO msg=request http.method=GET service=api\n"
+	if buf.String() != want {
+		t.Errorf("Output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestSetConsoleTheme tests the SetConsoleTheme option.
+// It verifies that the configured theme is used by the console output.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestSetConsoleTheme(t *testing.T) {
+	theme := DefaultConsoleTheme()
+	theme.Levels = nil
+
+	ctx := &loggerContext{}
+	SetConsoleTheme(theme)(ctx)
+
+	if ctx.consoleTheme == nil || ctx.consoleTheme.Levels != nil {
+		t.Fatal("SetConsoleTheme() didn't set the theme")
+	}
+
+	out := newOutput(&bytes.Buffer{})
+	out.console = true
+
+	handler, ok := out.handler(ctx).(*ConsoleHandler)
+	if !ok {
+		t.Fatal("Console output should use a ConsoleHandler for text format")
+	}
+
+	if len(handler.theme.Levels) != 0 {
+		t.Error("Console output should use the configured theme")
+	}
+}
@@ -9,20 +9,21 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"runtime"
 	"slices"
 	"strings"
 )
 
 // CustomTextHandler is a slog.Handler that formats logs as structured text.
 // It provides control over attribute ordering and supports all standard
-// slog.Handler functionality.
+// slog.Handler functionality. Attributes inside groups are written with
+// dotted keys, such as http.request.method=GET.
 type CustomTextHandler struct {
-	out       io.Writer
-	opts      *slog.HandlerOptions
-	attrOrder []string
-	attrs     []slog.Attr
-	groups    []string
+	out        io.Writer
+	opts       *slog.HandlerOptions
+	attrOrder  []string
+	attrs      []slog.Attr
+	groups     []string
+	extractors []ContextExtractor
 }
 
 // NewCustomTextHandler creates a new text handler with ordered attributes.
@@ -68,19 +69,66 @@ func (h *CustomTextHandler) Enabled(ctx context.Context, level slog.Level) bool
 // Returns:
 //   - error: Any error encountered during formatting or writing
 func (h *CustomTextHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs, stacks := h.collectAttrs(ctx, r)
+
+	// Build the output string with ordered attributes
+	var sb strings.Builder
+	for _, key := range h.orderedKeys(attrs) {
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(attrs[key])
+	}
+
+	sb.WriteString("\n")
+
+	// Stack traces follow on indented lines
+	writeStacks(&sb, stacks, plain, plain, plain)
+
+	// Write to output
+	_, err := h.out.Write([]byte(sb.String()))
+	return err
+}
+
+// collectAttrs gathers the standard, context, handler and record attributes of
+// a record as strings keyed by their fully qualified dotted keys. Stack traces
+// are returned separately because they are written on their own lines.
+//
+// Parameters:
+//   - ctx: The context for the logging operation, passed to the context extractors
+//   - r: The log record to process
+//
+// Returns:
+//   - map[string]string: The rendered attribute values by key
+//   - map[string]Stack: The stack traces by key
+func (h *CustomTextHandler) collectAttrs(ctx context.Context, r slog.Record) (map[string]string, map[string]Stack) {
 	// Collect all attributes in a map for reordering
 	attrs := make(map[string]string)
+	var stacks map[string]Stack
+	add := func(key string, val slog.Value) {
+		if stack, ok := stackValue(val); ok {
+			if stacks == nil {
+				stacks = make(map[string]Stack)
+			}
+			stacks[key] = stack
+			return
+		}
+		attrs[key] = val.String()
+	}
 
-	// Add standard attributes
-	attrs["time"] = r.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	// Add standard attributes, skipping a zero time as slog.Handler requires
+	if !r.Time.IsZero() {
+		attrs["time"] = r.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	}
 	attrs["level"] = levelToString(r.Level)
 	attrs["msg"] = r.Message
 
 	// Add source if enabled
 	if h.opts.AddSource {
 		if source := r.PC; source != 0 {
-			fs := runtime.CallersFrames([]uintptr{source})
-			frame, _ := fs.Next()
+			frame := sourceFrame(source)
 			if frame.File != "" {
 				shortFile := frame.File
 				// if lastSlash := strings.LastIndex(shortFile, "/"); lastSlash >= 0 { // Removed short source for full path
@@ -91,73 +139,63 @@ func (h *CustomTextHandler) Handle(ctx context.Context, r slog.Record) error {
 		}
 	}
 
-	// Process handler attributes (added via With())
+	// Process attributes extracted from the context, which are never inside groups
+	for _, attr := range extractAttrs(ctx, h.extractors) {
+		if slices.Contains(h.attrOrder, attr.Key) {
+			continue
+		}
+		flattenAttr(h.opts, nil, "", attr, add)
+	}
+
+	// Process handler attributes (added via With()), which are already flattened
 	for _, attr := range h.attrs {
 		if !slices.Contains(h.attrOrder, attr.Key) {
 			attrs[attr.Key] = attr.Value.String()
 		}
 	}
 
-	// Process record attributes
+	// Process record attributes inside the groups opened with WithGroup()
+	prefix := groupPrefix(h.groups)
 	r.Attrs(func(a slog.Attr) bool {
-		if !slices.Contains(h.attrOrder, a.Key) {
-			// Apply ReplaceAttr if provided
-			if h.opts.ReplaceAttr != nil {
-				a = h.opts.ReplaceAttr(nil, a)
-			}
-
-			// Only include non-empty attributes
-			if !a.Equal(slog.Attr{}) {
-				attrs[a.Key] = a.Value.String()
-			}
+		if prefix == "" && slices.Contains(h.attrOrder, a.Key) {
+			return true
 		}
+
+		flattenAttr(h.opts, h.groups, prefix, a, add)
 		return true
 	})
 
-	// Build the output string with ordered attributes
-	var sb strings.Builder
-
-	// First add the ordered attributes
-	for _, key := range h.attrOrder {
-		if val, ok := attrs[key]; ok {
-			// Skip empty values
-			if val == "" {
-				continue
-			}
+	return attrs, stacks
+}
 
-			if sb.Len() > 0 {
-				sb.WriteString(" ")
-			}
-			sb.WriteString(key)
-			sb.WriteString("=")
-			sb.WriteString(val)
+// orderedKeys returns the keys of the collected attributes in output order:
+// the keys from attrOrder first, then all remaining keys alphabetically.
+//
+// Parameters:
+//   - attrs: The collected attributes
+//
+// Returns:
+//   - []string: The keys in the order they should be written
+func (h *CustomTextHandler) orderedKeys(attrs map[string]string) []string {
+	keys := make([]string, 0, len(attrs))
 
-			// Remove from map to avoid duplicates
-			delete(attrs, key)
+	// First add the ordered attributes, skipping empty values
+	for _, key := range h.attrOrder {
+		if val, ok := attrs[key]; ok && val != "" {
+			keys = append(keys, key)
 		}
 	}
 
 	// Then add remaining attributes in alphabetical order
 	var remainingKeys []string
 	for k := range attrs {
-		remainingKeys = append(remainingKeys, k)
-	}
-	slices.Sort(remainingKeys)
-
-	for _, key := range remainingKeys {
-		if sb.Len() > 0 {
-			sb.WriteString(" ")
+		if !slices.Contains(keys, k) {
+			remainingKeys = append(remainingKeys, k)
 		}
-		sb.WriteString(key)
-		sb.WriteString("=")
-		sb.WriteString(attrs[key])
 	}
+	slices.Sort(remainingKeys)
 
-	sb.WriteString("\n")
-
-	// Write to output
-	_, err := h.out.Write([]byte(sb.String()))
-	return err
+	return append(keys, remainingKeys...)
 }
 
 // WithAttrs implements Handler.WithAttrs.
@@ -171,27 +209,20 @@ func (h *CustomTextHandler) Handle(ctx context.Context, r slog.Record) error {
 func (h *CustomTextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	// Create a new handler with the same settings
 	newHandler := &CustomTextHandler{
-		out:       h.out,
-		opts:      h.opts,
-		attrOrder: h.attrOrder,
-		attrs:     append([]slog.Attr{}, h.attrs...), // Copy existing attributes
-		groups:    append([]string{}, h.groups...),   // Copy existing groups
+		out:        h.out,
+		opts:       h.opts,
+		attrOrder:  h.attrOrder,
+		attrs:      append([]slog.Attr{}, h.attrs...), // Copy existing attributes
+		groups:     append([]string{}, h.groups...),   // Copy existing groups
+		extractors: h.extractors,
 	}
 
-	// Process and store the new attributes
+	// Flatten the new attributes once, using the groups open at this point
+	prefix := groupPrefix(h.groups)
 	for _, attr := range attrs {
-		// Apply ReplaceAttr if set
-		if h.opts != nil && h.opts.ReplaceAttr != nil {
-			attr = h.opts.ReplaceAttr(h.groups, attr)
-		}
-
-		// Skip empty attributes
-		if attr.Equal(slog.Attr{}) {
-			continue
-		}
-
-		// Add the attribute
-		newHandler.attrs = append(newHandler.attrs, attr)
+		flattenAttr(h.opts, h.groups, prefix, attr, func(key string, val slog.Value) {
+			newHandler.attrs = append(newHandler.attrs, slog.String(key, val.String()))
+		})
 	}
 
 	return newHandler
@@ -213,16 +244,89 @@ func (h *CustomTextHandler) WithGroup(name string) slog.Handler {
 
 	// Create a new handler with the same settings
 	newHandler := &CustomTextHandler{
-		out:       h.out,
-		opts:      h.opts,
-		attrOrder: h.attrOrder,
-		attrs:     append([]slog.Attr{}, h.attrs...),             // Copy existing attributes
-		groups:    append(append([]string{}, h.groups...), name), // Add the new group
+		out:        h.out,
+		opts:       h.opts,
+		attrOrder:  h.attrOrder,
+		attrs:      append([]slog.Attr{}, h.attrs...),             // Copy existing attributes
+		groups:     append(append([]string{}, h.groups...), name), // Add the new group
+		extractors: h.extractors,
 	}
 
 	return newHandler
 }
 
+// withExtractors returns a copy of the handler that writes the attributes
+// extracted from the context of every record.
+//
+// Parameters:
+//   - extractors: The context extractors
+//
+// Returns:
+//   - slog.Handler: A new handler that runs the extractors
+func (h *CustomTextHandler) withExtractors(extractors []ContextExtractor) slog.Handler {
+	newHandler := *h
+	newHandler.extractors = extractors
+	return &newHandler
+}
+
+// flattenAttr resolves an attribute and reports it with its fully qualified
+// dotted key. Group attributes are expanded recursively, empty groups are
+// dropped and groups with an empty key are inlined. Errors that are still
+// errors after ReplaceAttr are expanded into their message, type, fields and
+// wrapped errors (see Err).
+//
+// Parameters:
+//   - opts: The handler options providing ReplaceAttr, may be nil
+//   - groups: The groups enclosing the attribute, passed to ReplaceAttr
+//   - prefix: The dotted key prefix built from the enclosing groups
+//   - a: The attribute to flatten
+//   - emit: Called with the dotted key and the resolved value of every leaf attribute
+func flattenAttr(opts *slog.HandlerOptions, groups []string, prefix string, a slog.Attr, emit func(key string, val slog.Value)) {
+	err, isErr := resolveAttr(&a)
+
+	// Apply ReplaceAttr if provided; errors are passed as a whole before they are expanded
+	if opts != nil && opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+		a = opts.ReplaceAttr(groups, a)
+		err, isErr = resolveAttr(&a)
+	}
+	if isErr {
+		a.Value = errorValue(err, maxErrorDepth)
+	}
+
+	// Only include non-empty attributes
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		emit(prefix+a.Key, a.Value)
+		return
+	}
+
+	// Groups with an empty key are inlined, empty groups produce nothing
+	if a.Key != "" {
+		groups = append(slices.Clip(groups), a.Key)
+		prefix += a.Key + "."
+	}
+	for _, ga := range a.Value.Group() {
+		flattenAttr(opts, groups, prefix, ga, emit)
+	}
+}
+
+// groupPrefix builds the dotted key prefix for the given groups.
+//
+// Parameters:
+//   - groups: The names of the open groups
+//
+// Returns:
+//   - string: The group names joined by dots with a trailing dot, or an empty string
+func groupPrefix(groups []string) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	return strings.Join(groups, ".") + "."
+}
+
 // levelToString converts a slog.Level to its string representation.
 //
 // Parameters:
@@ -244,6 +348,8 @@ func levelToString(l slog.Level) string {
 		return "ERROR"
 	case LevelFatal:
 		return "FATAL"
+	case LevelPanic:
+		return "PANIC"
 	default:
 		return ""
 	}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"testing/slogtest"
 	"time"
 )
 
@@ -300,6 +301,142 @@ func TestCustomTextHandler_WithGroup(t *testing.T) {
 	}
 }
 
+// TestCustomTextHandler_Groups tests the rendering of grouped attributes.
+// It verifies that WithGroup and inline groups produce dotted key prefixes.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestCustomTextHandler_Groups(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	fixedTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		handler func(slog.Handler) slog.Handler
+		attrs   []slog.Attr
+		want    string
+	}{
+		{
+			name:    "inline group",
+			handler: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{
+				slog.Group("http", slog.Group("request", slog.String("method", "GET"))),
+			},
+			want: "level=INFO msg=grouped http.request.method=GET",
+		},
+		{
+			name: "WithGroup",
+			handler: func(h slog.Handler) slog.Handler {
+				return h.WithGroup("http").WithGroup("request")
+			},
+			attrs: []slog.Attr{slog.String("method", "GET")},
+			want:  "level=INFO msg=grouped http.request.method=GET",
+		},
+		{
+			name: "WithAttrs before and after WithGroup",
+			handler: func(h slog.Handler) slog.Handler {
+				return h.WithAttrs([]slog.Attr{slog.String("service", "api")}).
+					WithGroup("http").
+					WithAttrs([]slog.Attr{slog.Int("status", 200)})
+			},
+			attrs: []slog.Attr{slog.String("method", "GET")},
+			want:  "level=INFO msg=grouped http.method=GET http.status=200 service=api",
+		},
+		{
+			name:    "empty and anonymous groups",
+			handler: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{
+				slog.Group("empty"),
+				slog.Group("", slog.String("inlined", "yes")),
+			},
+			want: "level=INFO msg=grouped inlined=yes",
+		},
+		{
+			name: "open group without attributes",
+			handler: func(h slog.Handler) slog.Handler {
+				return h.WithGroup("http")
+			},
+			want: "level=INFO msg=grouped",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			handler := tt.handler(NewCustomTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+			r := slog.NewRecord(fixedTime, slog.LevelInfo, "grouped", 0)
+			r.AddAttrs(tt.attrs...)
+			if err := handler.Handle(context.Background(), r); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+
+			want := "time=2023-01-02T03:04:05.000Z " + tt.want + "\n"
+			if buf.String() != want {
+				t.Errorf("Output = %q, want %q", buf.String(), want)
+			}
+		})
+	}
+}
+
+// TestCustomTextHandler_SlogTest tests CustomTextHandler against the testing/slogtest suite.
+// It verifies that the handler behaves like a conformant slog.Handler.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestCustomTextHandler_SlogTest(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}
+
+	slogtest.Run(t, func(t *testing.T) slog.Handler {
+		buf.Reset()
+		return NewCustomTextHandler(&buf, opts)
+	}, func(t *testing.T) map[string]any {
+		return parseTextLine(t, buf.String())
+	})
+}
+
+// parseTextLine parses a line written by CustomTextHandler into nested maps,
+// splitting dotted keys into groups. Values must not contain spaces.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+//   - line: The log line to parse
+//
+// Returns:
+//   - map[string]any: The parsed attributes
+func parseTextLine(t *testing.T, line string) map[string]any {
+	t.Helper()
+
+	result := map[string]any{}
+	for _, field := range strings.Fields(line) {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			t.Fatalf("Malformed field %q in line %q", field, line)
+		}
+
+		m := result
+		keys := strings.Split(key, ".")
+		for _, group := range keys[:len(keys)-1] {
+			sub, ok := m[group].(map[string]any)
+			if !ok {
+				sub = map[string]any{}
+				m[group] = sub
+			}
+			m = sub
+		}
+		m[keys[len(keys)-1]] = val
+	}
+	return result
+}
+
 // TestLevelToString tests the levelToString function.
 // It verifies that the function correctly converts log levels to their string representations.
 //
@@ -319,6 +456,7 @@ func TestLevelToString(t *testing.T) {
 		{slog.LevelWarn, "WARN"},
 		{slog.LevelError, "ERROR"},
 		{LevelFatal, "FATAL"},
+		{LevelPanic, "PANIC"},
 		{slog.Level(999), ""}, // Unknown level
 	}
 
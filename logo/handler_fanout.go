@@ -0,0 +1,127 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the fan-out handler implementation which dispatches each
+// log record to one sub-handler per configured output.
+package logo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// FanoutHandler is a slog.Handler that forwards records to several sub-handlers.
+// Each sub-handler owns one output and applies its own format and level, while
+// the optional logger-wide level is checked once before any sub-handler runs.
+type FanoutHandler struct {
+	level    slog.Leveler
+	handlers []slog.Handler
+}
+
+// NewFanoutHandler creates a handler that dispatches every record to all of the
+// given handlers that are enabled for the record's level.
+//
+// Parameters:
+//   - level: The logger-wide minimum level, or nil to rely on the sub-handlers only
+//   - handlers: The sub-handlers, typically one per output
+//
+// Returns:
+//   - slog.Handler: A handler implementation that fans out to the sub-handlers
+func NewFanoutHandler(level slog.Leveler, handlers ...slog.Handler) slog.Handler {
+	return &FanoutHandler{
+		level:    level,
+		handlers: handlers,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+// It reports whether the level passes the logger-wide level, or the level set
+// for the context with WithLevel, and at least one sub-handler is enabled for it.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *FanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.level != nil && level < minLevel(ctx, h.level) {
+		return false
+	}
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements slog.Handler interface.
+// It passes a copy of the record to every sub-handler enabled for its level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: The errors of all failing sub-handlers joined together, or nil
+func (h *FanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a new fan-out handler whose sub-handlers all carry the attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *FanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &FanoutHandler{level: h.level, handlers: handlers}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a new fan-out handler whose sub-handlers all open the group.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *FanoutHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &FanoutHandler{level: h.level, handlers: handlers}
+}
+
+// withLeveler returns a fan-out handler with a different logger-wide level.
+// It is used by named loggers, which may log below the level of their root logger.
+//
+// Parameters:
+//   - wrap: Returns the new level given the current one
+//
+// Returns:
+//   - slog.Handler: A new handler sharing the sub-handlers
+func (h *FanoutHandler) withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	return &FanoutHandler{level: wrap(h.level), handlers: h.handlers}
+}
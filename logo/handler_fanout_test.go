@@ -0,0 +1,170 @@
+package logo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingWriter is an io.Writer that always returns an error.
+type failingWriter struct{}
+
+// Write implements io.Writer and always fails.
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+// TestFanoutHandler_Enabled tests the Enabled method of FanoutHandler.
+// It verifies that the logger-wide level is checked before the sub-handlers.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFanoutHandler_Enabled(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	debugHandler := NewCustomTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	warnHandler := NewCustomTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})
+
+	tests := []struct {
+		name     string
+		handler  slog.Handler
+		level    slog.Level
+		expected bool
+	}{
+		{
+			name:     "enabled by one sub-handler",
+			handler:  NewFanoutHandler(nil, warnHandler, debugHandler),
+			level:    slog.LevelDebug,
+			expected: true,
+		},
+		{
+			name:     "disabled by all sub-handlers",
+			handler:  NewFanoutHandler(nil, warnHandler),
+			level:    slog.LevelInfo,
+			expected: false,
+		},
+		{
+			name:     "disabled by logger-wide level",
+			handler:  NewFanoutHandler(slog.LevelError, debugHandler),
+			level:    slog.LevelWarn,
+			expected: false,
+		},
+		{
+			name:     "no sub-handlers",
+			handler:  NewFanoutHandler(slog.LevelInfo),
+			level:    slog.LevelError,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.handler.Enabled(context.Background(), tt.level); got != tt.expected {
+				t.Errorf("FanoutHandler.Enabled(%v) = %v, want %v", tt.level, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFanoutHandler_Handle tests the Handle method of FanoutHandler.
+// It verifies that each sub-handler only receives records at or above its own level.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFanoutHandler_Handle(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var textBuf, jsonBuf bytes.Buffer
+	handler := NewFanoutHandler(slog.LevelDebug,
+		NewCustomTextHandler(&textBuf, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		NewJSONHandler(&jsonBuf, &slog.HandlerOptions{Level: slog.LevelDebug}, false),
+	)
+
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo} {
+		r := slog.NewRecord(time.Now(), level, "message at "+levelToString(level), 0)
+		if err := handler.Handle(context.Background(), r); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+	}
+
+	if strings.Contains(textBuf.String(), "message at DEBUG") {
+		t.Errorf("Text output should not contain the debug record: %q", textBuf.String())
+	}
+	if !strings.Contains(textBuf.String(), "msg=message at INFO") {
+		t.Errorf("Text output should contain the info record: %q", textBuf.String())
+	}
+	if !strings.Contains(jsonBuf.String(), `"msg":"message at DEBUG"`) ||
+		!strings.Contains(jsonBuf.String(), `"msg":"message at INFO"`) {
+		t.Errorf("JSON output should contain both records: %q", jsonBuf.String())
+	}
+}
+
+// TestFanoutHandler_Handle_Errors tests that errors of sub-handlers are reported.
+// It verifies that a failing output does not prevent the others from being written.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFanoutHandler_Handle_Errors(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	handler := NewFanoutHandler(nil,
+		NewCustomTextHandler(failingWriter{}, opts),
+		NewCustomTextHandler(&buf, opts),
+	)
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "still written", 0)
+	if err := handler.Handle(context.Background(), r); err == nil {
+		t.Error("Handle() should return the error of the failing sub-handler")
+	}
+
+	if !strings.Contains(buf.String(), "still written") {
+		t.Errorf("Healthy output should still receive the record: %q", buf.String())
+	}
+}
+
+// TestFanoutHandler_WithAttrs tests the WithAttrs and WithGroup methods of FanoutHandler.
+// It verifies that derived handlers pass the attributes to every sub-handler.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFanoutHandler_WithAttrs(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf1, buf2 bytes.Buffer
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	handler := NewFanoutHandler(nil,
+		NewCustomTextHandler(&buf1, opts),
+		NewCustomTextHandler(&buf2, opts),
+	)
+
+	if handler.WithGroup("") != handler {
+		t.Error("WithGroup(\"\") should return the same handler")
+	}
+
+	derived := handler.WithAttrs([]slog.Attr{slog.String("request_id", "abc")})
+	if derived == handler {
+		t.Fatal("WithAttrs() should return a new handler instance")
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "derived", 0)
+	if err := derived.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	for i, buf := range []*bytes.Buffer{&buf1, &buf2} {
+		if !strings.Contains(buf.String(), "request_id=abc") {
+			t.Errorf("Output %d should contain the handler attribute: %q", i, buf.String())
+		}
+	}
+}
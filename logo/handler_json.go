@@ -10,18 +10,31 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"runtime"
 	"slices"
 )
 
 // JSONHandler is a slog.Handler that formats logs as JSON.
 // It provides control over pretty-printing and supports all standard
-// slog.Handler functionality.
+// slog.Handler functionality, including handler attributes and nested groups.
 type JSONHandler struct {
 	out         io.Writer
 	opts        *slog.HandlerOptions
 	prettyPrint bool
 	attrOrder   []string
+	attrs       []jsonAttrs
+	groups      []string
+	extractors  []ContextExtractor
+}
+
+// jsonGroup is a JSON object created by the handler for a group.
+// Only groups created by the handler are removed again when they stay empty.
+type jsonGroup map[string]interface{}
+
+// jsonAttrs holds attributes added with WithAttrs, already converted to JSON
+// values, together with the groups that were open when they were added.
+type jsonAttrs struct {
+	groups []string
+	values jsonGroup
 }
 
 // NewJSONHandler creates a new JSON handler with optional pretty printing.
@@ -67,72 +80,65 @@ func (h *JSONHandler) Enabled(ctx context.Context, level slog.Level) bool {
 // Returns:
 //   - error: Any error encountered during formatting or writing
 func (h *JSONHandler) Handle(ctx context.Context, r slog.Record) error {
-	// Create an ordered map to maintain attribute order
-	orderedMap := make(map[string]interface{})
+	// encoding/json writes object keys in sorted order
+	root := jsonGroup{}
 
-	// Add standard attributes in desired order
-	orderedMap["time"] = r.Time.Format("2006-01-02T15:04:05.000Z07:00")
-	orderedMap["level"] = levelToString(r.Level)
-	orderedMap["msg"] = r.Message
+	// Add standard attributes, skipping a zero time as slog.Handler requires
+	if !r.Time.IsZero() {
+		root["time"] = r.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	root["level"] = levelToString(r.Level)
+	root["msg"] = r.Message
 
 	// Add source if requested
 	if h.opts.AddSource {
 		if source := r.PC; source != 0 {
-			fs := runtime.CallersFrames([]uintptr{source})
-			frame, _ := fs.Next()
+			frame := sourceFrame(source)
 			if frame.File != "" {
 				shortFile := frame.File
 				// if lastSlash := strings.LastIndex(shortFile, "/"); lastSlash >= 0 { // Removed short source for full path
 				// 	shortFile = shortFile[lastSlash+1:]
 				// }
-				orderedMap["source"] = fmt.Sprintf("%s:%d", shortFile, frame.Line)
+				root["source"] = fmt.Sprintf("%s:%d", shortFile, frame.Line)
 			}
 		}
 	}
 
-	// Add attributes
-	otherAttrs := make(map[string]interface{})
-
-	r.Attrs(func(a slog.Attr) bool {
-		// Apply attribute transformations if specified
-		if h.opts.ReplaceAttr != nil {
-			a = h.opts.ReplaceAttr(nil, a)
+	// Add attributes extracted from the context, which are never inside groups
+	for _, a := range extractAttrs(ctx, h.extractors) {
+		if !slices.Contains(h.attrOrder, a.Key) {
+			h.addAttr(root, nil, a)
 		}
+	}
 
-		// Skip empty attributes
-		if a.Equal(slog.Attr{}) {
-			return true
-		}
+	// Add the preallocated handler attributes (added via With())
+	for _, ha := range h.attrs {
+		mergeJSONGroup(descendJSONGroup(root, ha.groups), ha.values)
+	}
 
+	// Add record attributes inside the groups opened with WithGroup()
+	target := descendJSONGroup(root, h.groups)
+	r.Attrs(func(a slog.Attr) bool {
 		// Skip attributes we've already handled
-		if slices.Contains(h.attrOrder, a.Key) {
+		if len(h.groups) == 0 && slices.Contains(h.attrOrder, a.Key) {
 			return true
 		}
 
-		// Add the attribute to our collection
-		otherAttrs[a.Key] = a.Value.Any()
+		h.addAttr(target, h.groups, a)
 		return true
 	})
 
-	// Add remaining attributes in alphabetical order
-	var keys []string
-	for k := range otherAttrs {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-
-	for _, k := range keys {
-		orderedMap[k] = otherAttrs[k]
-	}
+	// Groups without any attributes are not written
+	pruneJSONGroup(root)
 
 	// Convert to JSON
 	var jsonData []byte
 	var err error
 
 	if h.prettyPrint {
-		jsonData, err = json.MarshalIndent(orderedMap, "", "  ")
+		jsonData, err = json.MarshalIndent(root, "", "  ")
 	} else {
-		jsonData, err = json.Marshal(orderedMap)
+		jsonData, err = json.Marshal(root)
 	}
 
 	if err != nil {
@@ -145,7 +151,8 @@ func (h *JSONHandler) Handle(ctx context.Context, r slog.Record) error {
 }
 
 // WithAttrs implements slog.Handler interface.
-// It returns a new handler with the given attributes.
+// It returns a new handler with the given attributes converted to JSON values
+// once, so they do not need to be processed again for every record.
 //
 // Parameters:
 //   - attrs: The attributes to add to the handler
@@ -153,19 +160,216 @@ func (h *JSONHandler) Handle(ctx context.Context, r slog.Record) error {
 // Returns:
 //   - slog.Handler: A new handler instance with the attributes
 func (h *JSONHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	// Create a new handler with the same settings
-	return NewJSONHandler(h.out, h.opts, h.prettyPrint)
+	newHandler := h.clone()
+	if len(attrs) == 0 {
+		return newHandler
+	}
+
+	values := jsonGroup{}
+	for _, a := range attrs {
+		h.addAttr(values, h.groups, a)
+	}
+
+	// Handler attributes must not replace the standard fields at the top level
+	if len(h.groups) == 0 {
+		for _, key := range h.attrOrder {
+			delete(values, key)
+		}
+	}
+
+	newHandler.attrs = append(newHandler.attrs, jsonAttrs{groups: h.groups, values: values})
+	return newHandler
 }
 
 // WithGroup implements slog.Handler interface.
-// It returns a handler that adds the given group name to the attribute key path.
+// It returns a handler that nests all following attributes in a JSON object
+// with the given name.
 //
 // Parameters:
 //   - name: The group name
 //
 // Returns:
-//   - slog.Handler: A handler that adds the group name to the attribute key path
+//   - slog.Handler: A new handler that includes the specified group
 func (h *JSONHandler) WithGroup(name string) slog.Handler {
-	// Groups are not fully implemented in this simple handler
-	return h
+	// Skip empty group names
+	if name == "" {
+		return h
+	}
+
+	newHandler := h.clone()
+	newHandler.groups = append(slices.Clip(h.groups), name)
+	return newHandler
+}
+
+// clone returns a shallow copy of the handler whose attribute and group
+// slices can be appended to without affecting the original.
+//
+// Returns:
+//   - *JSONHandler: A copy of the handler
+func (h *JSONHandler) clone() *JSONHandler {
+	return &JSONHandler{
+		out:         h.out,
+		opts:        h.opts,
+		prettyPrint: h.prettyPrint,
+		attrOrder:   h.attrOrder,
+		attrs:       slices.Clip(h.attrs),
+		groups:      slices.Clip(h.groups),
+		extractors:  h.extractors,
+	}
+}
+
+// withExtractors returns a copy of the handler that writes the attributes
+// extracted from the context of every record.
+//
+// Parameters:
+//   - extractors: The context extractors
+//
+// Returns:
+//   - slog.Handler: A new handler that runs the extractors
+func (h *JSONHandler) withExtractors(extractors []ContextExtractor) slog.Handler {
+	newHandler := h.clone()
+	newHandler.extractors = extractors
+	return newHandler
+}
+
+// addAttr converts an attribute to a JSON value and stores it in the given object.
+// Values are resolved, group attributes become nested objects, and groups with
+// an empty key are inlined into the surrounding object. Errors that are still
+// errors after ReplaceAttr become objects with their message, type, fields and
+// wrapped errors (see Err).
+//
+// Parameters:
+//   - m: The JSON object receiving the attribute
+//   - groups: The groups enclosing the attribute, passed to ReplaceAttr
+//   - a: The attribute to add
+func (h *JSONHandler) addAttr(m jsonGroup, groups []string, a slog.Attr) {
+	err, isErr := resolveAttr(&a)
+
+	// Apply attribute transformations if specified; errors are passed as a whole
+	if h.opts.ReplaceAttr != nil && a.Value.Kind() != slog.KindGroup {
+		a = h.opts.ReplaceAttr(groups, a)
+		err, isErr = resolveAttr(&a)
+	}
+
+	// Skip empty attributes
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if isErr && a.Key != "" {
+		m[a.Key] = h.errorObject(append(slices.Clip(groups), a.Key), err, maxErrorDepth)
+		return
+	}
+
+	if a.Value.Kind() != slog.KindGroup {
+		m[a.Key] = a.Value.Any()
+		return
+	}
+
+	// Empty groups are ignored
+	attrs := a.Value.Group()
+	if len(attrs) == 0 {
+		return
+	}
+
+	// Groups with an empty key are inlined
+	if a.Key == "" {
+		for _, ga := range attrs {
+			h.addAttr(m, groups, ga)
+		}
+		return
+	}
+
+	sub := descendJSONGroup(m, []string{a.Key})
+	groups = append(slices.Clip(groups), a.Key)
+	for _, ga := range attrs {
+		h.addAttr(sub, groups, ga)
+	}
+}
+
+// errorObject converts an error into a JSON object. Unlike the text outputs,
+// the errors wrapped by errors.Join are written as an array.
+//
+// Parameters:
+//   - groups: The groups enclosing the keys of the object, passed to ReplaceAttr
+//   - err: The error
+//   - depth: The number of wrapped errors that may still be rendered
+//
+// Returns:
+//   - jsonGroup: The object describing the error
+func (h *JSONHandler) errorObject(groups []string, err error, depth int) jsonGroup {
+	obj := make(jsonGroup)
+	h.addAttr(obj, groups, slog.String("msg", err.Error()))
+	h.addAttr(obj, groups, slog.String("type", fmt.Sprintf("%T", err)))
+	if fields, ok := errorFields(err); ok {
+		h.addAttr(obj, groups, slog.Attr{Key: "fields", Value: fields})
+	}
+
+	if depth > 0 {
+		causes, joined := errorCauses(err)
+		if joined {
+			list := make([]jsonGroup, len(causes))
+			for i, cause := range causes {
+				list[i] = h.errorObject(append(slices.Clip(groups), "causes"), cause, depth-1)
+			}
+			obj["causes"] = list
+		} else if len(causes) == 1 {
+			obj["cause"] = h.errorObject(append(slices.Clip(groups), "cause"), causes[0], depth-1)
+		}
+	}
+
+	return obj
+}
+
+// descendJSONGroup returns the nested object for the given group path,
+// creating missing groups along the way.
+//
+// Parameters:
+//   - m: The object to start from
+//   - groups: The names of the nested groups
+//
+// Returns:
+//   - jsonGroup: The innermost object
+func descendJSONGroup(m jsonGroup, groups []string) jsonGroup {
+	for _, name := range groups {
+		sub, ok := m[name].(jsonGroup)
+		if !ok {
+			sub = jsonGroup{}
+			m[name] = sub
+		}
+		m = sub
+	}
+	return m
+}
+
+// mergeJSONGroup copies all values of src into dst, merging nested groups
+// instead of replacing them. Groups are copied so that src is never modified
+// by later records.
+//
+// Parameters:
+//   - dst: The object receiving the values
+//   - src: The object providing the values
+func mergeJSONGroup(dst, src jsonGroup) {
+	for k, v := range src {
+		if sub, ok := v.(jsonGroup); ok {
+			mergeJSONGroup(descendJSONGroup(dst, []string{k}), sub)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// pruneJSONGroup removes groups that ended up without any attributes.
+//
+// Parameters:
+//   - m: The object to clean up
+func pruneJSONGroup(m jsonGroup) {
+	for k, v := range m {
+		if sub, ok := v.(jsonGroup); ok {
+			pruneJSONGroup(sub)
+			if len(sub) == 0 {
+				delete(m, k)
+			}
+		}
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"testing/slogtest"
 	"time"
 )
 
@@ -288,10 +289,73 @@ func TestJSONHandler_WithAttrs(t *testing.T) {
 	if newHandler == handler {
 		t.Error("WithAttrs() should return a new handler instance")
 	}
+
+	// Verify the attributes are written with every record
+	for i := 0; i < 2; i++ {
+		buf.Reset()
+		r := slog.NewRecord(time.Now(), slog.LevelInfo, "with attrs", 0)
+		r.AddAttrs(slog.Int("iteration", i))
+		if err := newHandler.Handle(context.Background(), r); err != nil {
+			t.Fatalf("Handle() error = %v", err)
+		}
+
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v", err)
+		}
+
+		if parsed["key1"] != "value1" || parsed["key2"] != float64(42) {
+			t.Errorf("Output should contain the handler attributes, got: %v", parsed)
+		}
+		if parsed["iteration"] != float64(i) {
+			t.Errorf("Output should contain the record attribute, got: %v", parsed)
+		}
+	}
+
+	// Verify the original handler is unaffected
+	buf.Reset()
+	if err := handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "plain", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "key1") {
+		t.Errorf("Original handler should not contain the attributes: %s", buf.String())
+	}
+}
+
+// TestJSONHandler_WithAttrs_StandardKeys tests that handler attributes named
+// like the standard fields do not replace them.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestJSONHandler_WithAttrs_StandardKeys(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := slog.New(NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true}, false))
+
+	testLogger.With("msg", "override", "level", "DEBUG", "time", "never", "source", "nowhere", "user", "john").
+		WithGroup("g").With("msg", "nested").
+		Info("real")
+
+	var parsed map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output %q: %v", buf.String(), err)
+	}
+
+	if parsed["msg"] != "real" || parsed["level"] != "INFO" || parsed["user"] != "john" {
+		t.Errorf("Output = %v, want the record message and level with the other handler attributes", parsed)
+	}
+	if parsed["time"] == "never" || parsed["source"] == "nowhere" {
+		t.Errorf("Output = %v, want the record time and source", parsed)
+	}
+	if group, _ := parsed["g"].(map[string]any); group["msg"] != "nested" {
+		t.Errorf("Output = %v, want attributes inside groups to keep standard keys", parsed)
+	}
 }
 
 // TestJSONHandler_WithGroup tests the WithGroup method of JSONHandler.
-// It verifies the behavior of grouping in the JSON handler implementation.
+// It verifies that groups and handler attributes produce nested JSON objects.
 //
 // Parameters:
 //   - t: The testing instance used for assertions and test control
@@ -309,12 +373,66 @@ func TestJSONHandler_WithGroup(t *testing.T) {
 
 	handler := NewJSONHandler(&buf, opts, false)
 
-	newHandler := handler.WithGroup("test_group")
+	// An empty group name is ignored
+	if handler.WithGroup("") != handler {
+		t.Error("WithGroup(\"\") should return the same handler")
+	}
+
+	newHandler := handler.WithAttrs([]slog.Attr{slog.String("service", "api")}).
+		WithGroup("http").
+		WithAttrs([]slog.Attr{slog.String("method", "GET")}).
+		WithGroup("response")
+
+	if newHandler == handler {
+		t.Fatal("WithGroup() should return a new handler with the group information")
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "request", 0)
+	r.AddAttrs(slog.Int("status", 200), slog.Group("timing", slog.Int("ms", 12)))
+	if err := newHandler.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
 
-	// Since WithGroup is a no-op in this implementation, it should return the same handler
-	if newHandler != handler {
-		t.Error("WithGroup() should return the same handler for this implementation")
+	want := `{"http":{"method":"GET","response":{"status":200,"timing":{"ms":12}}},"level":"INFO","msg":"request","service":"api",`
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("Output = %s, want prefix %s", buf.String(), want)
 	}
+
+	// Open groups without attributes are omitted
+	buf.Reset()
+	if err := newHandler.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "empty", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	want = `{"http":{"method":"GET"},"level":"INFO","msg":"empty","service":"api"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Output = %s, want %s", buf.String(), want)
+	}
+}
+
+// TestJSONHandler_SlogTest tests JSONHandler against the testing/slogtest suite.
+// It verifies that the handler behaves like a conformant slog.Handler.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestJSONHandler_SlogTest(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	}
+
+	slogtest.Run(t, func(t *testing.T) slog.Handler {
+		buf.Reset()
+		return NewJSONHandler(&buf, opts, false)
+	}, func(t *testing.T) map[string]any {
+		var parsed map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+			t.Fatalf("Failed to parse JSON output %q: %v", buf.String(), err)
+		}
+		return parsed
+	})
 }
 
 // TestHandlerConsistency tests the consistency between JSONHandler and CustomTextHandler.
@@ -0,0 +1,205 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the record channel handler implementation which delivers
+// log records as structured entries on a Go channel.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Entry is a structured log record delivered by a RecordChannelHandler.
+// It allows consumers to use the level, message and attributes of a record
+// without parsing formatted text.
+type Entry struct {
+	// Time is the time of the record, zero if the record has no time
+	Time time.Time
+
+	// Level is the level of the record
+	Level slog.Level
+
+	// Message is the log message
+	Message string
+
+	// Source is the file and line of the log call, empty if source is disabled
+	Source string
+
+	// Attrs holds all attributes by their dotted key, e.g. "http.method"
+	Attrs map[string]any
+
+	// Groups lists the groups opened on the logger with WithGroup
+	Groups []string
+}
+
+// RecordChannelHandler is a slog.Handler that sends every record as an Entry
+// to a channel. By default, records are dropped when the channel is full so
+// that logging never blocks; WithOverflowPolicy selects another behavior.
+type RecordChannelHandler struct {
+	sender     *channelSender[Entry]
+	opts       *slog.HandlerOptions
+	attrs      []slog.Attr
+	groups     []string
+	extractors []ContextExtractor
+}
+
+// NewRecordChannelHandler creates a new handler that sends structured entries to a channel.
+//
+// Parameters:
+//   - ch: The channel to which entries will be sent
+//   - opts: Handler options including log level and attribute replacements
+//   - chOpts: Options selecting the overflow policy
+//
+// Returns:
+//   - slog.Handler: A handler implementation for structured channel output
+func NewRecordChannelHandler(ch chan Entry, opts *slog.HandlerOptions, chOpts ...ChannelOption) slog.Handler {
+	return &RecordChannelHandler{
+		sender: newChannelSender(ch, chOpts...),
+		opts:   opts,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+// It checks if the given log level should be processed based on the configured minimum level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *RecordChannelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	minLevel := h.opts.Level.Level()
+	return level >= minLevel
+}
+
+// Handle implements slog.Handler interface.
+// It converts the record to an Entry and sends it to the channel.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Always nil; entries that do not fit into the channel are handled by the overflow policy
+func (h *RecordChannelHandler) Handle(ctx context.Context, r slog.Record) error {
+	entry := Entry{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   make(map[string]any, len(h.attrs)+r.NumAttrs()),
+		Groups:  slices.Clone(h.groups),
+	}
+
+	// Add source if enabled
+	if h.opts.AddSource && r.PC != 0 {
+		frame := sourceFrame(r.PC)
+		if frame.File != "" {
+			entry.Source = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+	}
+
+	// Attributes extracted from the context are never inside groups
+	for _, attr := range extractAttrs(ctx, h.extractors) {
+		flattenAttr(h.opts, nil, "", attr, func(key string, val slog.Value) {
+			entry.Attrs[key] = val.Any()
+		})
+	}
+
+	// Handler attributes (added via With()) are already flattened
+	for _, attr := range h.attrs {
+		entry.Attrs[attr.Key] = attr.Value.Any()
+	}
+
+	prefix := groupPrefix(h.groups)
+	r.Attrs(func(a slog.Attr) bool {
+		flattenAttr(h.opts, h.groups, prefix, a, func(key string, val slog.Value) {
+			entry.Attrs[key] = val.Any()
+		})
+		return true
+	})
+
+	h.sender.send(entry)
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the channel was full.
+// The count is shared with all handlers derived through WithAttrs and WithGroup.
+//
+// Returns:
+//   - uint64: The total number of dropped entries
+func (h *RecordChannelHandler) Dropped() uint64 {
+	return h.sender.Dropped()
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a new handler with the given attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *RecordChannelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newHandler := h.clone()
+
+	// Flatten the new attributes once, using the groups open at this point
+	prefix := groupPrefix(h.groups)
+	for _, attr := range attrs {
+		flattenAttr(h.opts, h.groups, prefix, attr, func(key string, val slog.Value) {
+			newHandler.attrs = append(newHandler.attrs, slog.Attr{Key: key, Value: val})
+		})
+	}
+
+	return newHandler
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler that adds the given group name to the attribute key path.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *RecordChannelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	newHandler := h.clone()
+	newHandler.groups = append(newHandler.groups, name)
+	return newHandler
+}
+
+// clone returns a copy of the handler whose attribute and group slices can
+// be appended to without affecting the original.
+//
+// Returns:
+//   - *RecordChannelHandler: A copy of the handler
+func (h *RecordChannelHandler) clone() *RecordChannelHandler {
+	return &RecordChannelHandler{
+		sender:     h.sender,
+		opts:       h.opts,
+		attrs:      slices.Clip(h.attrs),
+		groups:     slices.Clip(h.groups),
+		extractors: h.extractors,
+	}
+}
+
+// withExtractors returns a copy of the handler that adds the attributes
+// extracted from the context of every record to its entry.
+//
+// Parameters:
+//   - extractors: The context extractors
+//
+// Returns:
+//   - slog.Handler: A new handler that runs the extractors
+func (h *RecordChannelHandler) withExtractors(extractors []ContextExtractor) slog.Handler {
+	newHandler := h.clone()
+	newHandler.extractors = extractors
+	return newHandler
+}
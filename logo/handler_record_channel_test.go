@@ -0,0 +1,162 @@
+package logo
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRecordChannelHandler_Handle tests the Handle method of RecordChannelHandler.
+// It verifies that records are delivered as structured entries.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestRecordChannelHandler_Handle(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan Entry, 1)
+	handler := NewRecordChannelHandler(ch, &slog.HandlerOptions{Level: slog.LevelInfo, AddSource: true})
+
+	fixedTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	pc, _, _, _ := runtime.Caller(0)
+	r := slog.NewRecord(fixedTime, slog.LevelWarn, "disk almost full", pc)
+	r.AddAttrs(slog.Int("percent", 93), slog.Group("disk", slog.String("mount", "/var")))
+
+	if err := handler.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	select {
+	case entry := <-ch:
+		if !entry.Time.Equal(fixedTime) || entry.Level != slog.LevelWarn || entry.Message != "disk almost full" {
+			t.Errorf("Entry has wrong time, level or message: %+v", entry)
+		}
+
+		if !strings.Contains(entry.Source, "handler_record_channel_test.go:") {
+			t.Errorf("Entry source = %q, want this test file", entry.Source)
+		}
+
+		if entry.Attrs["percent"] != int64(93) {
+			t.Errorf("Entry attribute percent = %#v, want 93", entry.Attrs["percent"])
+		}
+
+		if entry.Attrs["disk.mount"] != "/var" {
+			t.Errorf("Entry attribute disk.mount = %#v, want /var", entry.Attrs["disk.mount"])
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for entry on channel")
+	}
+}
+
+// TestRecordChannelHandler_WithGroup tests the WithAttrs and WithGroup methods of RecordChannelHandler.
+// It verifies that handler attributes and groups are reflected in the entries.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestRecordChannelHandler_WithGroup(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan Entry, 1)
+	handler := NewRecordChannelHandler(ch, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	if handler.WithGroup("") != handler {
+		t.Error("WithGroup(\"\") should return the same handler")
+	}
+
+	derived := handler.WithAttrs([]slog.Attr{slog.String("service", "api")}).
+		WithGroup("http").
+		WithAttrs([]slog.Attr{slog.String("method", "GET")})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "request", 0)
+	r.AddAttrs(slog.Int("status", 200))
+	if err := derived.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	entry := <-ch
+	want := map[string]any{
+		"service":     "api",
+		"http.method": "GET",
+		"http.status": int64(200),
+	}
+	for k, v := range want {
+		if entry.Attrs[k] != v {
+			t.Errorf("Entry attribute %q = %#v, want %#v", k, entry.Attrs[k], v)
+		}
+	}
+
+	if !slices.Equal(entry.Groups, []string{"http"}) {
+		t.Errorf("Entry groups = %v, want [http]", entry.Groups)
+	}
+
+	if entry.Source != "" {
+		t.Errorf("Entry source should be empty without AddSource, got %q", entry.Source)
+	}
+}
+
+// TestRecordChannelHandler_FullChannel tests the Handle method when the channel is full.
+// It verifies that handling does not block and drops the entry.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestRecordChannelHandler_FullChannel(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan Entry, 1)
+	ch <- Entry{Message: "existing entry"}
+	handler := NewRecordChannelHandler(ch, &slog.HandlerOptions{Level: slog.LevelInfo})
+
+	if err := handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "dropped", 0)); err != nil {
+		t.Errorf("Handle() error = %v", err)
+	}
+
+	if entry := <-ch; entry.Message != "existing entry" {
+		t.Errorf("Channel contained %q, want %q", entry.Message, "existing entry")
+	}
+}
+
+// TestAddRecordChannelOutput tests logging to a record channel output.
+// It verifies that the output applies its own level like other outputs.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestAddRecordChannelOutput(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan Entry, 5)
+	testLogger := NewLogger(
+		SetLevel(slog.LevelDebug),
+		DisableConsole(),
+		AddRecordChannelOutput(ch, OutputLevel(slog.LevelWarn)),
+	)
+
+	testLogger.Info("filtered by output level")
+	testLogger.With("component", "db").Error("query failed", "table", "users")
+
+	select {
+	case entry := <-ch:
+		if entry.Message != "query failed" || entry.Level != slog.LevelError {
+			t.Errorf("Unexpected entry: %+v", entry)
+		}
+
+		if entry.Attrs["component"] != "db" || entry.Attrs["table"] != "users" {
+			t.Errorf("Entry attributes = %v, want component and table", entry.Attrs)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Timeout waiting for entry on channel")
+	}
+
+	select {
+	case entry := <-ch:
+		t.Errorf("Channel unexpectedly contained extra entry: %+v", entry)
+	default:
+	}
+}
@@ -0,0 +1,268 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the swappable root handler which allows the handler graph
+// of a live logger to be replaced while log calls are in progress.
+package logo
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// handlerGraph is one generation of the handlers of a logger together with the
+// configuration it was built from.
+type handlerGraph struct {
+	handler slog.Handler
+	ctx     *loggerContext
+
+	// mu guards the count of log calls in progress on this graph, so that
+	// replacing the graph can wait for them without holding a lock across them
+	mu       sync.Mutex
+	inFlight int
+	retired  bool
+
+	// drained is closed once the graph is retired and no log call is in progress
+	drained chan struct{}
+}
+
+// newHandlerGraph creates a graph for a root handler.
+//
+// Parameters:
+//   - handler: The root handler of the graph
+//   - ctx: The configuration the graph was built from
+//
+// Returns:
+//   - *handlerGraph: The graph
+func newHandlerGraph(handler slog.Handler, ctx *loggerContext) *handlerGraph {
+	return &handlerGraph{handler: handler, ctx: ctx, drained: make(chan struct{})}
+}
+
+// enter registers a log call on the graph.
+//
+// Returns:
+//   - bool: False if the graph was retired and must not receive the record
+func (g *handlerGraph) enter() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.retired {
+		return false
+	}
+	g.inFlight++
+	return true
+}
+
+// leave marks a log call registered by enter as finished.
+func (g *handlerGraph) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inFlight--
+	if g.retired && g.inFlight == 0 {
+		close(g.drained)
+	}
+}
+
+// retire turns away all later log calls on the graph.
+//
+// Returns:
+//   - <-chan struct{}: Closed once the log calls in progress have finished
+func (g *handlerGraph) retire() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.retired {
+		g.retired = true
+		if g.inFlight == 0 {
+			close(g.drained)
+		}
+	}
+	return g.drained
+}
+
+// swapState is the state shared by a swapHandler and all handlers derived from it.
+type swapState struct {
+	current atomic.Pointer[handlerGraph]
+
+	// mu serializes replacements of the graph
+	mu sync.Mutex
+
+	// levels holds the level overrides of named loggers
+	levels namedLevels
+}
+
+// swapHandler is the root handler of every logger created by NewLogger.
+// It forwards records to the current handler graph, which can be replaced at
+// any time. Handlers derived with WithAttrs and WithGroup remember the calls
+// and replay them on each new graph.
+type swapHandler struct {
+	state *swapState
+	ops   []func(slog.Handler) slog.Handler
+
+	// derived caches the result of replaying ops on the current graph
+	derived atomic.Pointer[derivedHandler]
+}
+
+// derivedHandler is a handler derived from a specific graph.
+type derivedHandler struct {
+	graph   *handlerGraph
+	handler slog.Handler
+}
+
+// newSwapHandler creates a swappable handler for an initial handler graph.
+//
+// Parameters:
+//   - handler: The initial root handler of the graph
+//   - ctx: The configuration the graph was built from
+//
+// Returns:
+//   - *swapHandler: The swappable root handler
+func newSwapHandler(handler slog.Handler, ctx *loggerContext) *swapHandler {
+	s := &swapHandler{state: &swapState{}}
+	s.state.current.Store(newHandlerGraph(handler, ctx))
+	return s
+}
+
+// Enabled implements slog.Handler interface.
+// It reports whether the current handler graph is enabled for the level.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (s *swapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return s.resolve(s.state.current.Load()).Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler interface.
+// It passes the record to the current handler graph. A graph that is being
+// replaced finishes the records it already received, while new records go to
+// the new graph.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Any error returned by the current handler graph
+func (s *swapHandler) Handle(ctx context.Context, r slog.Record) error {
+	for {
+		g := s.state.current.Load()
+		if !g.enter() {
+			// The graph was replaced after it was loaded; use the new one
+			continue
+		}
+
+		err := s.resolve(g).Handle(ctx, r)
+		g.leave()
+		return err
+	}
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a handler that adds the attributes to the current and all future graphs.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (s *swapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return s.with(func(h slog.Handler) slog.Handler { return h.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler that opens the group on the current and all future graphs.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (s *swapHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return s
+	}
+	return s.with(func(h slog.Handler) slog.Handler { return h.WithGroup(name) })
+}
+
+// withLeveler returns a handler that replaces the logger-wide level on the
+// current and all future graphs.
+//
+// Parameters:
+//   - wrap: Returns the new level given the current one
+//
+// Returns:
+//   - slog.Handler: A new handler sharing the swap state
+func (s *swapHandler) withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	return s.with(func(h slog.Handler) slog.Handler { return withLeveler(h, wrap) })
+}
+
+// with returns a derived handler that applies op after the existing operations.
+//
+// Parameters:
+//   - op: The operation deriving a handler from its parent
+//
+// Returns:
+//   - *swapHandler: The derived handler sharing the swap state
+func (s *swapHandler) with(op func(slog.Handler) slog.Handler) *swapHandler {
+	return &swapHandler{
+		state: s.state,
+		ops:   append(slices.Clip(s.ops), op),
+	}
+}
+
+// resolve returns the handler to use for a graph, replaying the WithAttrs and
+// WithGroup calls of this handler on the graph's root handler.
+//
+// Parameters:
+//   - g: The graph to resolve against
+//
+// Returns:
+//   - slog.Handler: The handler of the graph for this derived handler
+func (s *swapHandler) resolve(g *handlerGraph) slog.Handler {
+	if len(s.ops) == 0 {
+		return g.handler
+	}
+
+	if d := s.derived.Load(); d != nil && d.graph == g {
+		return d.handler
+	}
+
+	h := g.handler
+	for _, op := range s.ops {
+		h = op(h)
+	}
+	s.derived.Store(&derivedHandler{graph: g, handler: h})
+	return h
+}
+
+// current returns the graph records are currently sent to.
+//
+// Returns:
+//   - *handlerGraph: The current graph
+func (s *swapHandler) current() *handlerGraph {
+	return s.state.current.Load()
+}
+
+// replace makes a new graph current and then waits until all log calls in
+// progress on the previous graph have finished. Log calls made meanwhile,
+// including ones made by the handlers of the previous graph, use the new graph.
+// The caller must hold state.mu.
+//
+// Parameters:
+//   - handler: The root handler of the new graph
+//   - ctx: The configuration the new graph was built from
+//
+// Returns:
+//   - *handlerGraph: The previous graph, which no longer receives records
+func (s *swapHandler) replace(handler slog.Handler, ctx *loggerContext) *handlerGraph {
+	old := s.state.current.Swap(newHandlerGraph(handler, ctx))
+
+	// Turn away calls that loaded the old graph late and wait for in-flight calls
+	<-old.retire()
+
+	return old
+}
@@ -7,6 +7,7 @@ package logo
 import (
 	"io"
 	"os"
+	"runtime"
 	"testing"
 )
 
@@ -47,6 +48,25 @@ func SuppressLogOutput(t *testing.T) func() {
 	}
 }
 
+// CallerLine returns the line of its caller, for tests that compare the source
+// or stack trace of a record with the line that logged it.
+//
+// Returns:
+//   - int: The line number
+func CallerLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+// CallerFile returns the path of the file of its caller.
+//
+// Returns:
+//   - string: The file path
+func CallerFile() string {
+	_, file, _, _ := runtime.Caller(1)
+	return file
+}
+
 // SetConsoleOutput sets a custom writer for the console output.
 // This is useful for testing to redirect console logs to a null device or buffer.
 //
@@ -57,40 +77,21 @@ func SuppressLogOutput(t *testing.T) func() {
 //   - LoggerOption: A logger option function that can be passed to Init() or NewLogger()
 func SetConsoleOutput(w io.Writer) LoggerOption {
 	return func(ctx *loggerContext) {
-		// Find and replace any existing console writers
-		// Look for StyledConsoleWriter or default os.Stdout/os.Stderr writers
+		// Find and replace the writer of any existing console output
 		hasConsoleWriter := false
-		for i, output := range ctx.outputs {
-			if _, ok := output.(*StyledConsoleWriter); ok {
-				// Replace existing styled writer
-				if ctx.useJSONFormat {
-					ctx.outputs[i] = w
-				} else {
-					ctx.outputs[i] = NewStyledConsoleWriter(w, ctx)
-				}
-				hasConsoleWriter = true
-				break
-			}
-
-			// Also check for direct stdout/stderr references
-			if output == os.Stdout || output == os.Stderr {
-				if ctx.useJSONFormat {
-					ctx.outputs[i] = w
-				} else {
-					ctx.outputs[i] = NewStyledConsoleWriter(w, ctx)
-				}
+		for _, out := range ctx.outputs {
+			if out.console {
+				out.w = w
 				hasConsoleWriter = true
 				break
 			}
 		}
 
-		// If no console writer found, add a new one
+		// If no console output found, add a new one
 		if !hasConsoleWriter {
-			if ctx.useJSONFormat {
-				ctx.outputs = append(ctx.outputs, w)
-			} else {
-				ctx.outputs = append(ctx.outputs, NewStyledConsoleWriter(w, ctx))
-			}
+			out := newOutput(w)
+			out.console = true
+			ctx.outputs = append(ctx.outputs, out)
 		}
 
 		// Mark that we've handled console output
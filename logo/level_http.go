@@ -0,0 +1,411 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the HTTP handler which allows the level of running
+// loggers to be read and changed without a restart.
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxLevelRequestSize limits the body of a level change request.
+const maxLevelRequestSize = 64 << 10
+
+// LevelHandlerOption is a functional option type for configuring the HTTP
+// handler returned by LevelHandler.
+type LevelHandlerOption func(*levelHandler)
+
+// AddNamedLogger makes an additional logger available through the level handler.
+// It is selected with the "logger" query parameter, e.g. "/log/level?logger=db".
+//
+// Parameters:
+//   - name: The name used to select the logger
+//   - l: The logger whose level can be read and changed
+//
+// Returns:
+//   - LevelHandlerOption: A function that can be passed to LevelHandler() to add the logger
+func AddNamedLogger(name string, l *Logger) LevelHandlerOption {
+	return func(h *levelHandler) {
+		h.named[name] = l
+	}
+}
+
+// RevertLevelAfter makes every level change through the handler temporary.
+// The previous level is restored after the duration unless the request
+// specifies its own "revert_after" value.
+//
+// Parameters:
+//   - d: How long a changed level stays in effect; zero disables reverting
+//
+// Returns:
+//   - LevelHandlerOption: A function that can be passed to LevelHandler() to set the default revert timeout
+func RevertLevelAfter(d time.Duration) LevelHandlerOption {
+	return func(h *levelHandler) {
+		h.revertAfter = d
+	}
+}
+
+// LevelHandler returns an http.Handler to read and change the level of a
+// running logger. Changes take effect immediately for every output of the
+// logger and for all loggers derived from it.
+//
+// GET returns the current level as JSON:
+//
+//	{"level":"INFO"}
+//
+// PUT and POST change the level. The level and an optional auto-revert timeout
+// are read from a JSON body or from form values:
+//
+//	curl -X PUT localhost:8080/log/level -d '{"level":"debug","revert_after":"10m"}'
+//	curl -X POST 'localhost:8080/log/level?logger=db&level=trace'
+//
+// The "logger" query parameter selects a logger added with AddNamedLogger, or
+// the loggers created with Named by name or pattern, e.g. "cache.redis" or
+// "cache.*" (see SetLevelFor). Levels are parsed with ParseLevel. The root
+// level of loggers using a custom handler cannot be changed.
+//
+// Parameters:
+//   - logger: The logger to control, or nil to control the global logger returned by L()
+//   - opts: Options adding named loggers or a default revert timeout
+//
+// Returns:
+//   - http.Handler: The handler serving the level endpoint
+func LevelHandler(logger *Logger, opts ...LevelHandlerOption) http.Handler {
+	h := &levelHandler{
+		root:    logger,
+		named:   make(map[string]*Logger),
+		reverts: make(map[revertKey]*levelRevert),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// levelHandler is the http.Handler returned by LevelHandler.
+type levelHandler struct {
+	root        *Logger
+	named       map[string]*Logger
+	revertAfter time.Duration
+
+	// mu protects the pending reverts
+	mu      sync.Mutex
+	reverts map[revertKey]*levelRevert
+}
+
+// revertKey identifies the level of a pending revert by logger and pattern
+// rather than by level variable, since Reconfigure replaces the variables.
+type revertKey struct {
+	state   *swapState
+	pattern string
+}
+
+// levelRevert is a pending restore of a temporarily changed level.
+type levelRevert struct {
+	previous slog.Level
+	created  *slog.LevelVar // The override added by the change, removed on revert
+	at       time.Time
+	timer    *time.Timer
+}
+
+// levelTarget is the level selected by a request.
+type levelTarget struct {
+	logger  *Logger        // The logger owning the level
+	level   *slog.LevelVar // nil if a name has no override yet
+	current slog.Level     // The level currently in effect for the selection
+	levels  *namedLevels   // The overrides of named loggers, nil for the root level
+	pattern string         // The name or pattern of the override
+	created bool           // True if the override was added for this request
+}
+
+// levelChange is a validated level change request.
+type levelChange struct {
+	level       slog.Level
+	revertAfter time.Duration
+}
+
+// levelRequest is the JSON body of a level change.
+type levelRequest struct {
+	Level       string `json:"level"`
+	RevertAfter string `json:"revert_after,omitempty"`
+}
+
+// levelResponse is the JSON representation of a logger's level.
+type levelResponse struct {
+	Logger   string     `json:"logger,omitempty"`
+	Level    string     `json:"level"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler interface.
+// It returns or changes the level of the selected logger.
+//
+// Parameters:
+//   - w: The writer for the HTTP response
+//   - r: The HTTP request
+func (h *levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("logger")
+
+	write := false
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		write = true
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeLevelError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	// Validate a change first, so a rejected request adds no override
+	var change levelChange
+	if write {
+		var status int
+		var err error
+		if change, status, err = h.readChange(r); err != nil {
+			writeLevelError(w, status, err)
+			return
+		}
+	}
+
+	target, status, err := h.target(name, write)
+	if err != nil {
+		writeLevelError(w, status, err)
+		return
+	}
+
+	if write {
+		h.setLevel(target, change)
+	}
+
+	resp := levelResponse{Logger: name, Level: levelName(target.current)}
+	if target.level != nil {
+		resp.Level = levelName(target.level.Level())
+		h.mu.Lock()
+		if revert := h.reverts[target.key()]; revert != nil {
+			resp.RevertAt = &revert.at
+		}
+		h.mu.Unlock()
+	}
+
+	writeLevelResponse(w, http.StatusOK, resp)
+}
+
+// target returns the level selected by the "logger" query parameter.
+// Loggers added with AddNamedLogger are looked up first. Other names select
+// the level override of the named loggers of the controlled logger; they may
+// also be patterns such as "cache.*". An override is only added for changes.
+//
+// Parameters:
+//   - name: The value of the "logger" query parameter
+//   - write: True if the level is going to be changed
+//
+// Returns:
+//   - levelTarget: The level to read or change
+//   - int: The HTTP status code to report if the selection is invalid
+//   - error: An error describing why the selection is invalid
+func (h *levelHandler) target(name string, write bool) (levelTarget, int, error) {
+	l, pattern := h.root, name
+	if named := h.named[name]; name != "" && named != nil {
+		l, pattern = named, named.name
+	} else if l == nil {
+		l = L()
+	}
+
+	ctx := l.config()
+	if ctx == nil || ctx.level == nil {
+		return levelTarget{}, http.StatusConflict, errors.New("the level of this logger cannot be changed")
+	}
+
+	if pattern == "" {
+		// Custom handlers do not apply the logger-wide level
+		if ctx.customHandler != nil {
+			return levelTarget{}, http.StatusConflict, errors.New("the level of this logger cannot be changed")
+		}
+		return levelTarget{logger: l, level: ctx.level, current: ctx.level.Level()}, http.StatusOK, nil
+	}
+
+	if err := checkLevelPattern(pattern); err != nil {
+		return levelTarget{}, http.StatusNotFound, fmt.Errorf("unknown logger %q: %v", name, err)
+	}
+
+	t := levelTarget{logger: l, levels: &l.root.state.levels, pattern: pattern, current: ctx.currentLevel()}
+	if v := t.levels.lookup(strings.TrimSuffix(pattern, ".*")); v != nil {
+		t.current = v.Level()
+	}
+
+	if write {
+		t.level, t.created = t.levels.levelVar(pattern, t.current)
+	} else {
+		t.level = t.levels.get(pattern)
+	}
+	return t, http.StatusOK, nil
+}
+
+// readChange reads and validates the level change of a request.
+//
+// Parameters:
+//   - r: The HTTP request holding the new level
+//
+// Returns:
+//   - levelChange: The new level and the revert timeout
+//   - int: The HTTP status code to report if the request is invalid
+//   - error: An error describing why the request is invalid
+func (h *levelHandler) readChange(r *http.Request) (levelChange, int, error) {
+	req, err := readLevelRequest(r)
+	if err != nil {
+		return levelChange{}, http.StatusBadRequest, err
+	}
+	if req.Level == "" {
+		return levelChange{}, http.StatusBadRequest, errors.New("missing level")
+	}
+
+	change := levelChange{revertAfter: h.revertAfter}
+	if change.level, err = ParseLevel(req.Level); err != nil {
+		return levelChange{}, http.StatusBadRequest, err
+	}
+
+	if req.RevertAfter != "" {
+		if change.revertAfter, err = time.ParseDuration(req.RevertAfter); err != nil || change.revertAfter < 0 {
+			return levelChange{}, http.StatusBadRequest, fmt.Errorf("invalid revert_after %q", req.RevertAfter)
+		}
+	}
+	return change, http.StatusOK, nil
+}
+
+// setLevel changes the selected level and schedules the revert. Reverting an
+// override that was added for a temporary change removes it again, so the
+// named loggers follow their root level afterwards. A revert applies to the
+// configuration of the logger at that time, so it survives Reconfigure.
+//
+// Parameters:
+//   - t: The selected level
+//   - change: The validated change
+func (h *levelHandler) setLevel(t levelTarget, change levelChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := t.key()
+
+	// A pending revert restores the level from before the first temporary change
+	previous, created := t.level.Level(), (*slog.LevelVar)(nil)
+	if t.created {
+		created = t.level
+	}
+	if pending := h.reverts[key]; pending != nil {
+		pending.timer.Stop()
+		previous, created = pending.previous, pending.created
+		delete(h.reverts, key)
+	}
+
+	t.level.Set(change.level)
+
+	if change.revertAfter > 0 {
+		revert := &levelRevert{previous: previous, created: created, at: time.Now().Add(change.revertAfter)}
+		revert.timer = time.AfterFunc(change.revertAfter, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.reverts[key] != revert {
+				return
+			}
+			delete(h.reverts, key)
+
+			if revert.created != nil {
+				t.levels.removeVar(t.pattern, revert.created)
+			} else if level := t.variable(); level != nil {
+				level.Set(revert.previous)
+			}
+		})
+		h.reverts[key] = revert
+	}
+}
+
+// key returns the key of the pending revert of the selected level.
+//
+// Returns:
+//   - revertKey: The logger and pattern of the level
+func (t levelTarget) key() revertKey {
+	return revertKey{state: t.logger.root.state, pattern: t.pattern}
+}
+
+// variable returns the current level variable of the selection, which changes
+// when the logger is reconfigured.
+//
+// Returns:
+//   - *slog.LevelVar: The level variable, or nil if it no longer exists
+func (t levelTarget) variable() *slog.LevelVar {
+	if t.levels != nil {
+		return t.levels.get(t.pattern)
+	}
+	if ctx := t.logger.config(); ctx != nil {
+		return ctx.level
+	}
+	return nil
+}
+
+// readLevelRequest reads a level change from a JSON body or from form values.
+// Bodies starting with "{" are decoded as JSON regardless of the content type,
+// so that "curl -d" works without setting a header.
+//
+// Parameters:
+//   - r: The HTTP request
+//
+// Returns:
+//   - levelRequest: The requested level and revert timeout
+//   - error: An error if the body cannot be read or decoded
+func readLevelRequest(r *http.Request) (levelRequest, error) {
+	var req levelRequest
+
+	if r.Body != nil {
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxLevelRequestSize))
+		if err != nil {
+			return req, fmt.Errorf("reading body: %w", err)
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			if err := json.Unmarshal(data, &req); err != nil {
+				return req, fmt.Errorf("invalid JSON body: %w", err)
+			}
+			return req, nil
+		}
+
+		// Let FormValue parse the body as form values
+		r.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	req.Level = strings.TrimSpace(r.FormValue("level"))
+	req.RevertAfter = strings.TrimSpace(r.FormValue("revert_after"))
+	return req, nil
+}
+
+// writeLevelResponse writes a JSON response.
+//
+// Parameters:
+//   - w: The writer for the HTTP response
+//   - status: The HTTP status code
+//   - resp: The response body
+func writeLevelResponse(w http.ResponseWriter, status int, resp levelResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// writeLevelError writes a JSON error response.
+//
+// Parameters:
+//   - w: The writer for the HTTP response
+//   - status: The HTTP status code
+//   - err: The error to report
+func writeLevelError(w http.ResponseWriter, status int, err error) {
+	writeLevelResponse(w, status, levelResponse{Error: err.Error()})
+}
@@ -0,0 +1,231 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the HTTP level handler.
+package logo
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveLevel sends a request to a level handler and decodes the response.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+//   - h: The level handler
+//   - method: The HTTP method
+//   - target: The request URL
+//   - body: The request body, or empty for none
+//   - contentType: The Content-Type header, or empty for none
+//
+// Returns:
+//   - int: The HTTP status code
+//   - levelResponse: The decoded response body
+func serveLevel(t *testing.T, h http.Handler, method, target, body, contentType string) (int, levelResponse) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp levelResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("%s %s: decoding response: %v", method, target, err)
+	}
+	return rec.Code, resp
+}
+
+// TestLevelHandler tests reading and changing levels over HTTP.
+// It verifies GET, PUT with a JSON body, POST with form values, named
+// loggers and the error responses.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLevelHandler(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	root := NewLogger(DisableConsole(), AddWriterOutput(w))
+	db := NewLogger(DisableConsole(), SetLevel(slog.LevelWarn))
+	h := LevelHandler(root, AddNamedLogger("db", db))
+
+	if code, resp := serveLevel(t, h, http.MethodGet, "/", "", ""); code != http.StatusOK || resp.Level != "INFO" {
+		t.Errorf("GET = %d %+v, want 200 INFO", code, resp)
+	}
+
+	child := root.With("component", "api")
+	code, resp := serveLevel(t, h, http.MethodPut, "/", `{"level":"debug"}`, "application/json")
+	if code != http.StatusOK || resp.Level != "DEBUG" {
+		t.Fatalf("PUT = %d %+v, want 200 DEBUG", code, resp)
+	}
+	child.Debug("visible")
+	if !strings.Contains(w.String(), "msg=visible") || GetCurrentLevel(root) != slog.LevelDebug {
+		t.Errorf("Level change did not apply to the derived logger, output %q", w.String())
+	}
+
+	code, resp = serveLevel(t, h, http.MethodPost, "/?logger=db", "level=trace", "application/x-www-form-urlencoded")
+	if code != http.StatusOK || resp.Logger != "db" || resp.Level != "TRACE" || !IsLevelEnabled(LevelTrace, db) {
+		t.Errorf("POST named logger = %d %+v, want 200 TRACE", code, resp)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"invalid logger pattern", http.MethodGet, "/?logger=ca*che", "", http.StatusNotFound},
+		{"invalid level", http.MethodPut, "/", `{"level":"verbose"}`, http.StatusBadRequest},
+		{"missing level", http.MethodPost, "/", "", http.StatusBadRequest},
+		{"invalid revert", http.MethodPut, "/?level=info&revert_after=soon", "", http.StatusBadRequest},
+		{"invalid JSON", http.MethodPut, "/", `{"level":`, http.StatusBadRequest},
+		{"unsupported method", http.MethodDelete, "/", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp := serveLevel(t, h, tt.method, tt.target, tt.body, "")
+			if code != tt.want || resp.Error == "" {
+				t.Errorf("%s %s = %d %+v, want %d with an error", tt.method, tt.target, code, resp, tt.want)
+			}
+		})
+	}
+
+	if GetCurrentLevel(root) != slog.LevelDebug {
+		t.Errorf("Failed requests changed the level to %v", GetCurrentLevel(root))
+	}
+
+	custom := NewLogger(UseCustomHandler(slog.NewTextHandler(w, nil)))
+	if code, _ := serveLevel(t, LevelHandler(custom), http.MethodGet, "/", "", ""); code != http.StatusConflict {
+		t.Errorf("GET for custom handler = %d, want %d", code, http.StatusConflict)
+	}
+}
+
+// TestLevelHandler_Revert tests the auto-revert of temporary level changes.
+// It verifies that the level before the first temporary change is restored.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLevelHandler_Revert(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	testLogger := NewLogger(DisableConsole())
+	h := LevelHandler(testLogger, RevertLevelAfter(time.Hour))
+
+	code, resp := serveLevel(t, h, http.MethodPut, "/?level=debug", "", "")
+	if code != http.StatusOK || resp.RevertAt == nil {
+		t.Fatalf("PUT = %d %+v, want a revert time", code, resp)
+	}
+
+	// A second temporary change restores the original level, not the first change
+	if code, _ := serveLevel(t, h, http.MethodPut, `/`, `{"level":"trace","revert_after":"20ms"}`, ""); code != http.StatusOK {
+		t.Fatalf("PUT = %d, want 200", code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for GetCurrentLevel(testLogger) != slog.LevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level was not reverted, still %v", GetCurrentLevel(testLogger))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if code, resp := serveLevel(t, h, http.MethodGet, "/", "", ""); code != http.StatusOK || resp.RevertAt != nil {
+		t.Errorf("GET after revert = %d %+v, want no pending revert", code, resp)
+	}
+
+	// A change with revert_after 0 is permanent despite the default
+	serveLevel(t, h, http.MethodPut, "/?level=warn&revert_after=0", "", "")
+	if _, resp := serveLevel(t, h, http.MethodGet, "/", "", ""); resp.Level != "WARN" || resp.RevertAt != nil {
+		t.Errorf("GET = %+v, want a permanent WARN", resp)
+	}
+}
+
+// TestLevelHandler_NamedOverride tests that changes of named loggers only add
+// an override if they succeed, and that reverting an added override removes it.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLevelHandler_NamedOverride(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w))
+	db := testLogger.Named("db")
+	h := LevelHandler(testLogger)
+
+	if code, _ := serveLevel(t, h, http.MethodPut, "/?logger=db&level=bogus", "", ""); code != http.StatusBadRequest {
+		t.Fatalf("PUT with an invalid level = %d, want %d", code, http.StatusBadRequest)
+	}
+	if _, resp := serveLevel(t, h, http.MethodGet, "/?logger=db", "", ""); resp.RevertAt != nil || resp.Level != "INFO" {
+		t.Errorf("GET after a failed PUT = %+v, want INFO", resp)
+	}
+
+	// The named logger still follows the root level
+	SetLoggerLevel(testLogger, slog.LevelDebug)
+	db.Debug("follows root")
+	if !strings.Contains(w.String(), "msg=follows root") {
+		t.Errorf("Named logger did not follow the root level after a failed PUT, output %q", w.String())
+	}
+
+	// A temporary override is removed on revert
+	if code, _ := serveLevel(t, h, http.MethodPut, "/?logger=db&level=error&revert_after=20ms", "", ""); code != http.StatusOK {
+		t.Fatalf("PUT = %d, want 200", code)
+	}
+	levels := &testLogger.root.state.levels
+	deadline := time.Now().Add(5 * time.Second)
+	for levels.get("db") != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Override was not removed on revert")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	SetLoggerLevel(testLogger, LevelTrace)
+	if !IsLevelEnabled(LevelTrace, db) {
+		t.Error("Named logger did not follow the root level after the revert")
+	}
+}
+
+// TestLevelHandler_RevertAfterReconfigure tests that a pending revert is kept
+// when the logger is reconfigured and applies to the new configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLevelHandler_RevertAfterReconfigure(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	testLogger := NewLogger(DisableConsole())
+	h := LevelHandler(testLogger)
+
+	if code, _ := serveLevel(t, h, http.MethodPut, "/?level=debug&revert_after=50ms", "", ""); code != http.StatusOK {
+		t.Fatalf("PUT = %d, want 200", code)
+	}
+	if err := testLogger.Reconfigure(DisableConsole(), SetLevel(LevelTrace)); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	if _, resp := serveLevel(t, h, http.MethodGet, "/", "", ""); resp.Level != "TRACE" || resp.RevertAt == nil {
+		t.Errorf("GET after Reconfigure = %+v, want TRACE with a pending revert", resp)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for GetCurrentLevel(testLogger) != slog.LevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level was not reverted, still %v", GetCurrentLevel(testLogger))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -5,12 +5,14 @@
 package logo
 
 import (
-	"io"
+	"fmt"
 	"log/slog"
+	"strings"
 )
 
 // IsLevelEnabled checks if a log level is enabled based on the current logger configuration.
-// When used with the global logger, it checks against the global log level.
+// When used with the global logger, it checks against the level of the logger returned by L(),
+// or against the global default level if Init has not been called yet.
 // When used with a specific logger instance, it checks against that logger's level.
 //
 // Parameters:
@@ -20,19 +22,13 @@ import (
 // Returns:
 //   - bool: True if the level is enabled, false otherwise
 func IsLevelEnabled(level slog.Level, logger *Logger) bool {
-	if logger != nil && logger.ctx != nil {
-		// Check against the specific logger's level
-		return level >= logger.ctx.logLevel
-	}
-
-	// Fall back to global level
-	mu.RLock()
-	defer mu.RUnlock()
-	return level >= LOGLEVEL
+	return level >= GetCurrentLevel(logger)
 }
 
-// GetCurrentLevel returns the log level configured for a logger.
-// When used with the global logger, it returns the global log level.
+// GetCurrentLevel returns the log level configured for a logger, including
+// changes made at runtime with SetLoggerLevel, LevelHandler or signals.
+// When used with the global logger, it returns the level of the logger returned by L(),
+// or the global default level if Init has not been called yet.
 // When used with a specific logger instance, it returns that logger's level.
 //
 // Parameters:
@@ -41,9 +37,20 @@ func IsLevelEnabled(level slog.Level, logger *Logger) bool {
 // Returns:
 //   - slog.Level: The current log level
 func GetCurrentLevel(logger *Logger) slog.Level {
-	if logger != nil && logger.ctx != nil {
+	if logger == nil {
+		logger = L()
+	}
+
+	if ctx := logger.config(); ctx != nil {
+		// Named loggers use the most specific override of their name
+		if logger.name != "" && logger.root != nil {
+			if v := logger.root.state.levels.lookup(logger.name); v != nil {
+				return v.Level()
+			}
+		}
+
 		// Return the specific logger's level
-		return logger.ctx.logLevel
+		return ctx.currentLevel()
 	}
 
 	// Fall back to global level
@@ -53,62 +60,90 @@ func GetCurrentLevel(logger *Logger) slog.Level {
 }
 
 // SetGlobalLevel sets the log level for the global logger.
-// This affects all subsequent log messages through the global logger (via L()).
+// This affects all subsequent log messages through the global logger (via L()),
+// including loggers derived from it, and is the default level of loggers
+// created afterwards.
 //
 // Parameters:
 //   - level: The new log level to set
 func SetGlobalLevel(level slog.Level) {
 	mu.Lock()
-	defer mu.Unlock()
 	LOGLEVEL = level
+	l := logger
+	mu.Unlock()
+
+	SetLoggerLevel(l, level)
 }
 
 // SetLoggerLevel sets the log level for a specific logger instance.
+// For a logger created with Named, only the level of that name is changed.
+// The level is held in a slog.LevelVar shared by all handlers of the logger,
+// so the change is atomic, applies to every output at once and keeps loggers
+// derived with With and WithGroup. It is safe to call while other goroutines
+// are logging.
 //
 // Parameters:
 //   - logger: The logger instance to configure
 //   - level: The new log level to set
 func SetLoggerLevel(logger *Logger, level slog.Level) {
-	if logger != nil && logger.ctx != nil {
-		// Update the context log level
-		logger.ctx.logLevel = level
+	ctx := logger.config()
+	if ctx == nil || ctx.level == nil {
+		return
+	}
 
-		// Update the handler's log level if it supports it
-		if handler := logger.Handler(); handler != nil {
-			// Try to update the level via LevelVar if available
-			if leveler, ok := handler.(interface{ SetLevel(slog.Level) }); ok {
-				leveler.SetLevel(level)
-				return
-			}
+	// For named loggers set the override of the exact name, leaving the root level alone
+	if logger.name != "" && logger.root != nil {
+		logger.root.state.levels.set(logger.name, level)
+		return
+	}
 
-			// If the handler doesn't directly support SetLevel, we might need to replace it
-			// Create a new handler with the updated level
-			var newHandler slog.Handler
+	ctx.level.Set(level)
 
-			// Configure handler options with the new level
-			handlerOptions := &slog.HandlerOptions{
-				Level:     level,
-				AddSource: logger.ctx.includeSource,
-				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-					// Preserve any existing attribute replacement logic
-					return a
-				},
-			}
+	// Custom handlers apply their own level; forward the change if they support it
+	if leveler, ok := ctx.customHandler.(interface{ SetLevel(slog.Level) }); ok {
+		leveler.SetLevel(level)
+	}
+}
 
-			// Create a multi-writer for all outputs
-			if len(logger.ctx.outputs) > 0 {
-				multiwriter := io.MultiWriter(logger.ctx.outputs...)
+// levelName returns the name of a level as accepted by ParseLevel.
+//
+// Parameters:
+//   - level: The level to name
+//
+// Returns:
+//   - string: The name of the level (e.g., "TRACE", "DEBUG", "INFO+2")
+func levelName(level slog.Level) string {
+	if name := levelToString(level); name != "" {
+		return name
+	}
+	return level.String()
+}
 
-				// Recreate the appropriate handler type
-				if logger.ctx.useJSONFormat {
-					newHandler = NewJSONHandler(multiwriter, handlerOptions, logger.ctx.jsonPretty)
-				} else {
-					newHandler = NewCustomTextHandler(multiwriter, handlerOptions)
-				}
+// ParseLevel converts a level name to a slog.Level.
+// Names are case-insensitive and include the additional levels TRACE, FATAL
+// and PANIC. Offsets in the slog notation, such as "INFO+2", are accepted as well.
+//
+// Parameters:
+//   - name: The level name (e.g., "debug", "WARN", "trace")
+//
+// Returns:
+//   - slog.Level: The parsed level
+//   - error: An error if the name is not a known level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "TRACE":
+		return LevelTrace, nil
+	case "FATAL":
+		return LevelFatal, nil
+	case "PANIC":
+		return LevelPanic, nil
+	case "WARNING":
+		return slog.LevelWarn, nil
+	}
 
-				// Replace the logger's handler
-				logger.Logger = slog.New(newHandler)
-			}
-		}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return 0, fmt.Errorf("unknown level %q", name)
 	}
+	return level, nil
 }
@@ -0,0 +1,119 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for querying, changing and parsing log levels.
+package logo
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestParseLevel tests the ParseLevel function.
+// It verifies that level names, including the additional levels, are parsed case-insensitively.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"trace", LevelTrace, false},
+		{"DEBUG", slog.LevelDebug, false},
+		{" Info ", slog.LevelInfo, false},
+		{"warning", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"Fatal", LevelFatal, false},
+		{"panic", LevelPanic, false},
+		{"INFO+2", slog.LevelInfo + 2, false},
+		{"loud", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevel(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetLoggerLevel tests changing the level of a logger at runtime.
+// It verifies that loggers derived with With and WithGroup keep their
+// attributes and follow the new level, also while other goroutines log.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestSetLoggerLevel(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), AddWriterOutput(newOpenWriter()))
+	child := testLogger.With("component", "db").WithGroup("query")
+	handler := testLogger.Handler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				child.Info("concurrent")
+			}
+		}()
+	}
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelWarn, slog.LevelInfo, slog.LevelDebug} {
+		SetLoggerLevel(testLogger, level)
+	}
+	wg.Wait()
+
+	if testLogger.Handler() != handler {
+		t.Error("SetLoggerLevel() replaced the handler")
+	}
+	if !IsLevelEnabled(slog.LevelDebug, testLogger) || GetCurrentLevel(testLogger) != slog.LevelDebug {
+		t.Errorf("GetCurrentLevel() = %v, want %v", GetCurrentLevel(testLogger), slog.LevelDebug)
+	}
+
+	child.Debug("after", "table", "users")
+	if got := w.String(); !strings.Contains(got, "msg=after component=db query.table=users") {
+		t.Errorf("Derived logger lost its attributes or level, output %q", got)
+	}
+}
+
+// TestSetGlobalLevel tests that SetGlobalLevel changes the level of the global
+// logger and of loggers obtained from L() before the change.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestSetGlobalLevel(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	defer SetGlobalLevel(LOGLEVEL)
+
+	w := newOpenWriter()
+	Init(DisableConsole(), AddWriterOutput(w))
+	log := L()
+
+	SetGlobalLevel(slog.LevelDebug)
+	log.Debug("visible")
+
+	if !IsLevelEnabled(slog.LevelDebug, nil) || GetCurrentLevel(nil) != slog.LevelDebug {
+		t.Errorf("GetCurrentLevel(nil) = %v, want %v", GetCurrentLevel(nil), slog.LevelDebug)
+	}
+	if !strings.Contains(w.String(), "msg=visible") {
+		t.Errorf("Global logger did not use the new level, output %q", w.String())
+	}
+	if l := NewLogger(DisableConsole()); GetCurrentLevel(l) != slog.LevelDebug {
+		t.Errorf("New logger level = %v, want the global level %v", GetCurrentLevel(l), slog.LevelDebug)
+	}
+}
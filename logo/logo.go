@@ -1,6 +1,6 @@
 // Package logo provides a flexible and extensible structured logging framework
 // built on top of Go's standard library slog package. It enhances slog with features
-// like customizable outputs, log levels including TRACE and FATAL, colorized console
+// like customizable outputs, log levels including TRACE, FATAL and PANIC, colorized console
 // output, file rotation, log channels, and support for JSON formatting.
 //
 // This package supports multiple logging backends simultaneously, including console,
@@ -28,17 +28,25 @@
 //	logo.Init(
 //		logo.UseJSON(true), // true for pretty-printed JSON
 //	)
+//
+// Each output can override the format, level and source settings:
+//
+//	logo.Init(
+//		logo.SetLevel(slog.LevelDebug),
+//		logo.AddConsoleOutput(logo.OutputLevel(slog.LevelInfo)),
+//		logo.AddFileOutput("/path/to/log.file", 10, 3, 30, true, logo.OutputFormat(logo.FormatJSON)),
+//	)
 package logo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
-	"runtime"
-	"runtime/debug"
 	"sync"
 	"time"
 
@@ -85,6 +93,10 @@ var (
 	LevelError = slog.LevelError
 )
 
+// ErrInvalidOption is wrapped by the errors of options that were given invalid
+// arguments or conflict with other options. Use errors.Is to detect it.
+var ErrInvalidOption = errors.New("invalid logger option")
+
 // osExit is a variable that points to os.Exit to allow for testing
 // of the Fatal function without actually terminating the program.
 var osExit = os.Exit
@@ -100,20 +112,48 @@ const (
 	// LevelFatal is a level above ERROR that indicates a fatal error condition
 	// which will cause the application to terminate after logging
 	LevelFatal slog.Level = slog.LevelError + 1
+
+	// LevelPanic is the highest level and indicates an error condition that
+	// causes a panic after logging
+	LevelPanic slog.Level = LevelFatal + 1
 )
 
 // loggerContext holds all the configuration for a specific logger instance
 type loggerContext struct {
-	outputs            []io.Writer
+	outputs            []*output
 	consoleOn          bool
 	useJSONFormat      bool
 	jsonPretty         bool
 	includeSource      bool
 	includeStackTraces bool
 	logLevel           slog.Level
+	level              *slog.LevelVar
 	colorEnabled       bool
 	fileWriters        []*lumberjack.Logger
+	createdPaths       []string
 	customHandler      slog.Handler
+	consoleTheme       *ConsoleTheme
+	dropReportInterval time.Duration
+	dropCounters       []droppedCounter
+	closers            []func() error
+	asyncQueueSize     int
+	asyncPolicy        OverflowPolicy
+	async              *asyncQueue
+	exitCode           int
+	exitHooks          []func()
+	exitTimeout        time.Duration
+	jsonRequested      bool
+	errs               []error
+	forceColor         bool
+	colorSet           bool
+	lateOpts           []LoggerOption
+	opts               []LoggerOption
+	watch              *configWatch
+	levelSignals       []os.Signal
+	namedLevels        map[string]slog.Level
+	extractors         []ContextExtractor
+	stackDepth         int
+	stackLevel         slog.Leveler
 }
 
 // LoggerOption is a functional option type for configuring the logger.
@@ -126,12 +166,17 @@ type LoggerOption func(*loggerContext)
 // convenience methods for different log levels.
 type Logger struct {
 	*slog.Logger
-	ctx *loggerContext // Contains all configuration including file writers
+	ctx        *loggerContext                    // Contains all configuration including file writers
+	root       *swapHandler                      // Allows the handler graph to be replaced by Reconfigure
+	name       string                            // The name given with Named
+	ops        []func(*slog.Logger) *slog.Logger // The derivations replayed by Named after the name
+	callerSkip int                               // The number of wrapper frames skipped for the source
 }
 
 // Init initializes the global default logger with the given options.
 // This configures a single global logger instance used by L().
 // To create independent loggers with their own configurations, use NewLogger() instead.
+// Invalid options are reported on os.Stderr; use InitE to handle them instead.
 func Init(opts ...LoggerOption) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -140,13 +185,71 @@ func Init(opts ...LoggerOption) {
 	logger = NewLogger(opts...)
 }
 
+// InitE initializes the global default logger like Init, but returns an error
+// if any of the options is invalid. In that case the global logger is left unchanged.
+//
+// Parameters:
+//   - opts: The options configuring the logger
+//
+// Returns:
+//   - error: The errors of all failing options joined together, or nil
+func InitE(opts ...LoggerOption) error {
+	l, err := NewLoggerE(opts...)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	logger = l
+	return nil
+}
+
 // NewLogger creates a new independent logger instance with its own configuration.
 // Unlike Init() which configures a global singleton logger, NewLogger returns a
 // completely separate logger that can be configured differently from other loggers.
+// Invalid options are reported on os.Stderr and skipped; use NewLoggerE to handle them instead.
 func NewLogger(opts ...LoggerOption) *Logger {
+	ctx := newLoggerContext(opts...)
+	if err := ctx.err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error configuring logger: %v\n", err)
+	}
+
+	return newLoggerFromContext(ctx)
+}
+
+// NewLoggerE creates a new independent logger like NewLogger, but returns an
+// error instead of a logger if any of the options is invalid. All failing
+// options are reported, joined with errors.Join, and the log files and
+// directories created by the options are removed again.
+//
+// Parameters:
+//   - opts: The options configuring the logger
+//
+// Returns:
+//   - *Logger: The configured logger, or nil if an option failed
+//   - error: The errors of all failing options joined together, or nil
+func NewLoggerE(opts ...LoggerOption) (*Logger, error) {
+	ctx := newLoggerContext(opts...)
+	if err := ctx.err(); err != nil {
+		ctx.discard()
+		return nil, err
+	}
+
+	return newLoggerFromContext(ctx), nil
+}
+
+// newLoggerContext creates a configuration context from the global defaults
+// and applies the options to it.
+//
+// Parameters:
+//   - opts: The options configuring the logger
+//
+// Returns:
+//   - *loggerContext: The configured context, including the errors of failing options
+func newLoggerContext(opts ...LoggerOption) *loggerContext {
 	// Create a configuration context for this specific logger
 	ctx := &loggerContext{
-		outputs:            OUTPUTS,
 		consoleOn:          CONSOLEON,
 		useJSONFormat:      USEJSONFORMAT,
 		jsonPretty:         JSONPRETTY,
@@ -155,6 +258,13 @@ func NewLogger(opts ...LoggerOption) *Logger {
 		logLevel:           LOGLEVEL,
 		colorEnabled:       COLORENABLED,
 		fileWriters:        nil,
+		exitCode:           1,
+		opts:               opts,
+	}
+
+	// Writers configured globally use the logger-wide settings
+	for _, w := range OUTPUTS {
+		ctx.outputs = append(ctx.outputs, newOutput(w))
 	}
 
 	// Apply all options to this context
@@ -162,56 +272,175 @@ func NewLogger(opts ...LoggerOption) *Logger {
 		opt(ctx)
 	}
 
-	// If a custom handler was specified, use it directly
+	// Options that override the code configuration, such as FromEnv, run last
+	for _, opt := range ctx.lateOpts {
+		opt(ctx)
+	}
+
+	// Check combinations of options that only conflict with each other
+	if ctx.customHandler != nil && ctx.jsonRequested {
+		ctx.addError(fmt.Errorf("%w: UseJSON has no effect together with UseCustomHandler", ErrInvalidOption))
+	}
+
+	return ctx
+}
+
+// newLoggerFromContext creates the logger and starts its background work.
+//
+// Parameters:
+//   - ctx: The configured logger context
+//
+// Returns:
+//   - *Logger: The logger using the context
+func newLoggerFromContext(ctx *loggerContext) *Logger {
+	root := newSwapHandler(ctx.newHandler(), ctx)
+	root.state.levels.reset(ctx.namedLevels)
+
+	// Create the logger
+	l := &Logger{
+		Logger: slog.New(root),
+		ctx:    ctx, // Store the context with file writers
+		root:   root,
+	}
+
+	l.start(ctx)
+	return l
+}
+
+// newHandler creates the root handler for a logger context, starting the
+// background writer first if asynchronous logging is enabled.
+//
+// Returns:
+//   - slog.Handler: The root handler of the handler graph
+func (ctx *loggerContext) newHandler() slog.Handler {
+	// The configured level can be changed at runtime through the variable
+	ctx.level = new(slog.LevelVar)
+	ctx.level.Set(ctx.logLevel)
+
+	// Start the background writer before any handler is built so all of them share it
+	if ctx.asyncQueueSize > 0 {
+		ctx.async = newAsyncQueue(ctx.asyncQueueSize, WithOverflowPolicy(ctx.asyncPolicy))
+		ctx.closers = append(ctx.closers, ctx.async.close)
+		ctx.dropCounters = append(ctx.dropCounters, ctx.async.sender)
+	}
+
 	if ctx.customHandler != nil {
-		return &Logger{
-			Logger: slog.New(ctx.customHandler),
-			ctx:    ctx,
-		}
+		// If a custom handler was specified, use it directly
+		return ctx.wrapAsync(withExtractors(ctx.customHandler, ctx.extractors))
 	}
 
 	// If no outputs are specified, default to console output unless disabled manually
 	if ctx.consoleOn && len(ctx.outputs) == 0 {
-		if !ctx.useJSONFormat {
-			// Only use styled writer for text format
-			ctx.outputs = append(ctx.outputs, NewStyledConsoleWriter(os.Stdout, ctx))
-		} else {
-			// For JSON, use stdout directly
-			ctx.outputs = append(ctx.outputs, os.Stdout)
-		}
+		ctx.outputs = append(ctx.outputs, newConsoleOutput())
 	}
+	return buildHandler(ctx)
+}
 
-	// Configure handler options
-	handlerOptions := &slog.HandlerOptions{
-		Level:     ctx.logLevel,
-		AddSource: ctx.includeSource,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Handle attribute replacements if needed
-			return a
-		},
+// start starts the background work of a logger context, such as drop reports,
+// the config file watcher and the level control by signals. The work is
+// stopped when the context is closed.
+//
+// Parameters:
+//   - ctx: The logger context whose handler graph is current
+func (l *Logger) start(ctx *loggerContext) {
+	if ctx.dropReportInterval > 0 && len(ctx.dropCounters) > 0 {
+		startDropReporter(ctx, ctx.dropReportInterval)
+	}
+	if ctx.watch != nil {
+		ctx.watch.start(l, ctx)
 	}
+	if len(ctx.levelSignals) > 0 {
+		startSignalLevelControl(l, ctx)
+	}
+}
 
-	// Create the handler
-	var handler slog.Handler
-	if len(ctx.outputs) > 0 {
-		multiwriter := io.MultiWriter(ctx.outputs...)
+// config returns the configuration of the logger's current handler graph.
+// It differs from the configuration the logger was created with after Reconfigure.
+//
+// Returns:
+//   - *loggerContext: The current configuration, or nil for loggers without one
+func (l *Logger) config() *loggerContext {
+	if l == nil {
+		return nil
+	}
+	if l.root != nil {
+		return l.root.current().ctx
+	}
+	return l.ctx
+}
 
-		// Choose handler based on format
-		if ctx.useJSONFormat {
-			handler = NewJSONHandler(multiwriter, handlerOptions, ctx.jsonPretty)
-		} else {
-			handler = NewCustomTextHandler(multiwriter, handlerOptions)
-		}
-	} else {
-		// Fallback to a no-op handler if no outputs
-		handler = slog.NewTextHandler(io.Discard, handlerOptions)
+// addError records an error of a failing option.
+//
+// Parameters:
+//   - err: The error describing why the option failed
+func (ctx *loggerContext) addError(err error) {
+	ctx.errs = append(ctx.errs, err)
+}
+
+// currentLevel returns the logger-wide level, including changes made at runtime.
+//
+// Returns:
+//   - slog.Level: The current minimum level of the logger
+func (ctx *loggerContext) currentLevel() slog.Level {
+	if ctx.level != nil {
+		return ctx.level.Level()
 	}
+	return ctx.logLevel
+}
 
-	// Create and return the logger
-	return &Logger{
-		Logger: slog.New(handler),
-		ctx:    ctx, // Store the context with file writers
+// err returns the errors of all failing options.
+//
+// Returns:
+//   - error: The recorded errors joined together, or nil if all options succeeded
+func (ctx *loggerContext) err() error {
+	return errors.Join(ctx.errs...)
+}
+
+// buildHandler creates the handler graph for a logger context.
+// Every output gets its own sub-handler, and all of them are combined in a
+// FanoutHandler that applies the logger-wide level variable. With EnableAsync the
+// graph is wrapped in an AsyncHandler using the logger's queue.
+//
+// Parameters:
+//   - ctx: The logger context holding the outputs and settings
+//
+// Returns:
+//   - slog.Handler: The root handler for the logger
+func buildHandler(ctx *loggerContext) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(ctx.outputs))
+	for _, out := range ctx.outputs {
+		handlers = append(handlers, withExtractors(out.handler(ctx), ctx.extractors))
+	}
+
+	// With no outputs the fan-out handler has nothing to dispatch to and acts as a no-op
+	return ctx.wrapAsync(NewFanoutHandler(ctx.level, handlers...))
+}
+
+// wrapAsync wraps the handler in an AsyncHandler if asynchronous logging is enabled.
+//
+// Parameters:
+//   - h: The handler that writes the records
+//
+// Returns:
+//   - slog.Handler: The asynchronous wrapper, or h itself for synchronous logging
+func (ctx *loggerContext) wrapAsync(h slog.Handler) slog.Handler {
+	if ctx.async == nil {
+		return h
 	}
+	return &AsyncHandler{next: h, queue: ctx.async}
+}
+
+// newConsoleOutput creates the standard output console sink.
+//
+// Parameters:
+//   - opts: Options overriding the logger-wide settings for the console
+//
+// Returns:
+//   - *output: An output writing to os.Stdout that is marked as the console
+func newConsoleOutput(opts ...OutputOption) *output {
+	o := newOutput(os.Stdout, opts...)
+	o.console = true
+	return o
 }
 
 // SetLevel sets the minimum log level that will be logged.
@@ -237,6 +466,21 @@ func SetLevel(level slog.Level) LoggerOption {
 func DisableColors() LoggerOption {
 	return func(ctx *loggerContext) {
 		ctx.colorEnabled = false
+		ctx.colorSet = true
+	}
+}
+
+// SetConsoleTheme sets the styles used for colored console output.
+// Start from DefaultConsoleTheme() to change only some of the styles.
+//
+// Parameters:
+//   - theme: The console theme to use
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the console theme
+func SetConsoleTheme(theme ConsoleTheme) LoggerOption {
+	return func(ctx *loggerContext) {
+		ctx.consoleTheme = &theme
 	}
 }
 
@@ -275,6 +519,29 @@ func UseJSON(pretty bool) LoggerOption {
 	return func(ctx *loggerContext) {
 		ctx.useJSONFormat = true
 		ctx.jsonPretty = pretty
+		ctx.jsonRequested = true
+	}
+}
+
+// EnableAsync makes the logger write records on a background goroutine.
+// Log calls only queue the record, so slow outputs such as files on a busy
+// disk do not stall the goroutines that log. Flush and Close wait until the
+// queue is drained, and Fatal flushes before exiting.
+//
+// Parameters:
+//   - queueSize: The number of records that can be queued
+//   - policy: What to do when the queue is full (e.g., Block, DropNewest)
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to enable asynchronous logging
+func EnableAsync(queueSize int, policy OverflowPolicy) LoggerOption {
+	return func(ctx *loggerContext) {
+		if queueSize < 0 {
+			ctx.addError(fmt.Errorf("%w: EnableAsync: negative queue size %d", ErrInvalidOption, queueSize))
+			return
+		}
+		ctx.asyncQueueSize = queueSize
+		ctx.asyncPolicy = policy
 	}
 }
 
@@ -284,28 +551,27 @@ func UseJSON(pretty bool) LoggerOption {
 // This is useful when you want to explicitly enable console output
 // even when other outputs are configured.
 //
+// Parameters:
+//   - opts: Optional settings overriding the logger-wide format, level or source for the console
+//
 // Returns:
 //   - LoggerOption: A function that can be passed to Init() to enable console output
-func AddConsoleOutput() LoggerOption {
+func AddConsoleOutput(opts ...OutputOption) LoggerOption {
 	return func(ctx *loggerContext) {
 		hasConsoleWriter := false
-		for _, output := range ctx.outputs {
-			if _, ok := output.(*StyledConsoleWriter); ok {
-				hasConsoleWriter = true
-				break
-			}
-			if output == os.Stdout {
+		for _, out := range ctx.outputs {
+			if out.console {
+				// Apply the options to the existing console output
+				for _, opt := range opts {
+					opt(out)
+				}
 				hasConsoleWriter = true
 				break
 			}
 		}
 
 		if !hasConsoleWriter {
-			if ctx.useJSONFormat {
-				ctx.outputs = append(ctx.outputs, os.Stdout)
-			} else {
-				ctx.outputs = append(ctx.outputs, NewStyledConsoleWriter(os.Stdout, ctx))
-			}
+			ctx.outputs = append(ctx.outputs, newConsoleOutput(opts...))
 		}
 		ctx.consoleOn = true
 	}
@@ -339,10 +605,7 @@ func UseCustomHandler(h slog.Handler) LoggerOption {
 			// For backward compatibility with direct calls
 			mu.Lock()
 			defer mu.Unlock()
-			logger = &Logger{
-				Logger: slog.New(h),
-				ctx:    &loggerContext{},
-			}
+			logger = newLoggerFromContext(&loggerContext{exitCode: 1, customHandler: h})
 		}
 	}
 }
@@ -361,7 +624,8 @@ func DisableConsole() LoggerOption {
 
 // AddFileOutput adds file output to the logger with rotation support.
 // This allows log messages to be written to a file, with automatic rotation
-// when the file reaches the specified maximum size.
+// when the file reaches the specified maximum size. Negative rotation settings
+// and files that cannot be created are reported by NewLoggerE and InitE.
 //
 // Parameters:
 //   - filepath: The path to the log file
@@ -369,18 +633,36 @@ func DisableConsole() LoggerOption {
 //   - maxBackups: Maximum number of old log files to retain
 //   - maxAgeDays: Maximum number of days to retain old log files
 //   - compress: If true, rotated log files will be compressed using gzip
+//   - opts: Optional settings overriding the logger-wide format, level or source for this file
 //
 // Returns:
 //   - LoggerOption: A function that can be passed to Init() to add file output
-func AddFileOutput(filename string, maxSize, maxBackups, maxAge int, compress bool) LoggerOption {
+func AddFileOutput(filename string, maxSize, maxBackups, maxAge int, compress bool, opts ...OutputOption) LoggerOption {
 	return func(ctx *loggerContext) {
+		// Reject rotation settings lumberjack would silently misinterpret
+		var invalid []error
+		for _, setting := range []struct {
+			name  string
+			value int
+		}{{"maxSize", maxSize}, {"maxBackups", maxBackups}, {"maxAge", maxAge}} {
+			if setting.value < 0 {
+				invalid = append(invalid, fmt.Errorf("%w: AddFileOutput(%q): negative %s %d", ErrInvalidOption, filename, setting.name, setting.value))
+			}
+		}
+		if len(invalid) > 0 {
+			ctx.errs = append(ctx.errs, invalid...)
+			return
+		}
+
 		// Ensure directory exists
 		dir := filepath.Dir(filename)
 		if dir != "" && dir != "." {
+			missing := missingPaths(dir)
 			if err := os.MkdirAll(dir, 0755); err != nil {
-				fmt.Fprintf(os.Stderr, "Error creating log directory: %v\n", err)
+				ctx.addError(fmt.Errorf("AddFileOutput(%q): creating log directory: %w", filename, err))
 				return
 			}
+			ctx.createdPaths = append(ctx.createdPaths, missing...)
 		}
 
 		fileWriter := &lumberjack.Logger{
@@ -392,18 +674,57 @@ func AddFileOutput(filename string, maxSize, maxBackups, maxAge int, compress bo
 		}
 
 		// Test that the file can be created
+		missing := missingPaths(filename)
 		if f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err == nil {
 			f.Close()
+			ctx.createdPaths = append(ctx.createdPaths, missing...)
 		} else {
-			fmt.Fprintf(os.Stderr, "Error testing log file creation: %v\n", err)
+			ctx.addError(fmt.Errorf("AddFileOutput(%q): opening log file: %w", filename, err))
 			return
 		}
 
-		ctx.outputs = append(ctx.outputs, fileWriter)
+		ctx.outputs = append(ctx.outputs, newOutput(fileWriter, opts...))
 		ctx.fileWriters = append(ctx.fileWriters, fileWriter)
 	}
 }
 
+// missingPaths returns the path and those of its parent directories that do
+// not exist yet, outermost first.
+//
+// Parameters:
+//   - path: The path of a file or directory that is about to be created
+//
+// Returns:
+//   - []string: The paths that creating path will create
+func missingPaths(path string) []string {
+	var missing []string
+	for {
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return missing
+		}
+		missing = append([]string{path}, missing...)
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return missing
+		}
+		path = parent
+	}
+}
+
+// discard releases what the options of a configuration opened or created,
+// for a configuration that is rejected before any logger used it.
+func (ctx *loggerContext) discard() {
+	for _, fw := range ctx.fileWriters {
+		fw.Close()
+	}
+
+	// Remove innermost first; directories that got other entries meanwhile are kept
+	for i := len(ctx.createdPaths) - 1; i >= 0; i-- {
+		os.Remove(ctx.createdPaths[i])
+	}
+}
+
 // Close properly closes all resources used by the logger.
 // This ensures that all log messages are flushed and file handles are closed.
 // It should be called before the application exits.
@@ -424,14 +745,39 @@ func Close() error {
 
 // Close properly closes all resources used by this logger instance
 func (l *Logger) Close() error {
-	if l == nil || l.ctx == nil {
+	if l == nil {
+		return nil
+	}
+
+	// Keep Reconfigure from replacing the configuration while it is closed
+	if l.root != nil {
+		l.root.state.mu.Lock()
+		defer l.root.state.mu.Unlock()
+	}
+
+	ctx := l.config()
+	if ctx == nil {
 		return nil
 	}
+	return ctx.close()
+}
 
+// close closes all resources of a logger context.
+//
+// Returns:
+//   - error: Any error encountered while closing resources
+func (ctx *loggerContext) close() error {
 	var lastErr error
 
+	// Stop background work started for this logger, most recently started first
+	for i := len(ctx.closers) - 1; i >= 0; i-- {
+		if err := ctx.closers[i](); err != nil {
+			lastErr = err
+		}
+	}
+
 	// Close all file writers
-	for _, fw := range l.ctx.fileWriters {
+	for _, fw := range ctx.fileWriters {
 		if fw != nil {
 			if err := fw.Close(); err != nil {
 				lastErr = err
@@ -440,28 +786,116 @@ func (l *Logger) Close() error {
 	}
 
 	// Also sync any other writers that might implement Sync()
-	for _, out := range l.ctx.outputs {
-		if syncer, ok := out.(interface{ Sync() error }); ok {
-			if err := syncer.Sync(); err != nil && lastErr == nil {
+	if err := ctx.sync(); err != nil && lastErr == nil {
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// sync syncs the writers of all outputs that implement Sync().
+//
+// Returns:
+//   - error: The last error returned by a writer
+func (ctx *loggerContext) sync() error {
+	var lastErr error
+	for _, out := range ctx.outputs {
+		if syncer, ok := out.w.(interface{ Sync() error }); ok {
+			if err := syncer.Sync(); err != nil {
 				lastErr = err
 			}
 		}
 	}
-
 	return lastErr
 }
 
+// Flush waits until all records queued by the global logger have been written.
+// It only has an effect if the logger was initialized with EnableAsync.
+//
+// Returns:
+//   - error: Any error encountered while flushing
+func Flush() error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if logger == nil {
+		return nil
+	}
+
+	return logger.Flush()
+}
+
+// Flush waits until all records queued by this logger instance have been written.
+// It only has an effect if the logger was created with EnableAsync.
+//
+// Returns:
+//   - error: Any error encountered while flushing
+func (l *Logger) Flush() error {
+	ctx := l.config()
+	if ctx == nil || ctx.async == nil {
+		return nil
+	}
+
+	return ctx.async.flush()
+}
+
 // AddChannelOutput adds a channel output to the logger.
 // This allows log messages to be sent to a channel for further processing or handling.
 //
 // Parameters:
 //   - ch: A channel of strings that will receive log messages
+//   - opts: Optional settings overriding the logger-wide format, level, source or overflow policy for this channel
 //
 // Returns:
 //   - LoggerOption: A function that can be passed to Init() to add channel output
-func AddChannelOutput(ch chan string) LoggerOption {
+func AddChannelOutput(ch chan string, opts ...OutputOption) LoggerOption {
+	return func(ctx *loggerContext) {
+		out := newOutput(nil, opts...)
+		writer := NewChannelWriter(ch, out.channelOpts...)
+		out.w = writer
+		ctx.outputs = append(ctx.outputs, out)
+		ctx.dropCounters = append(ctx.dropCounters, writer)
+	}
+}
+
+// AddRecordChannelOutput adds a structured channel output to the logger.
+// Unlike AddChannelOutput, every record is delivered as an Entry holding the
+// time, level, message, source and attributes, so consumers do not need to
+// parse formatted text. Format options do not apply to this output.
+//
+// Parameters:
+//   - ch: A channel of entries that will receive log records
+//   - opts: Optional settings overriding the logger-wide level, source or overflow policy for this channel
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to add record channel output
+func AddRecordChannelOutput(ch chan Entry, opts ...OutputOption) LoggerOption {
+	return func(ctx *loggerContext) {
+		out := newOutput(nil, opts...)
+
+		// The sender is shared by every handler built for this output so drops are counted once
+		sender := newChannelSender(ch, out.channelOpts...)
+		out.newHandler = func(handlerOptions *slog.HandlerOptions) slog.Handler {
+			return &RecordChannelHandler{sender: sender, opts: handlerOptions}
+		}
+		ctx.outputs = append(ctx.outputs, out)
+		ctx.dropCounters = append(ctx.dropCounters, sender)
+	}
+}
+
+// AddWriterOutput adds an arbitrary io.Writer as an output of the logger.
+// This allows log messages to be sent to destinations not covered by the
+// other outputs, such as network connections or in-memory buffers.
+//
+// Parameters:
+//   - w: The writer that will receive formatted log messages
+//   - opts: Optional settings overriding the logger-wide format, level or source for this writer
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to add the writer output
+func AddWriterOutput(w io.Writer, opts ...OutputOption) LoggerOption {
 	return func(ctx *loggerContext) {
-		ctx.outputs = append(ctx.outputs, NewChannelWriter(ch))
+		ctx.outputs = append(ctx.outputs, newOutput(w, opts...))
 	}
 }
 
@@ -489,11 +923,38 @@ func L() *Logger {
 // Returns:
 //   - None
 func (l *Logger) Trace(msg string, attrs ...any) {
-	if !l.Enabled(context.Background(), LevelTrace) {
+	l.logTrace(context.Background(), msg, attrs)
+}
+
+// TraceContext logs like Trace with the given context, which is passed to the
+// context extractors and may lower the level with WithLevel.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - attrs: Additional attributes to include with the log entry,
+//     provided as alternating keys and values
+//
+// Returns:
+//   - None
+func (l *Logger) TraceContext(ctx context.Context, msg string, attrs ...any) {
+	l.logTrace(ctx, msg, attrs)
+}
+
+// logTrace logs the record of Trace, TraceContext or Tracef with the caller's
+// source and a stack trace.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - attrs: Additional attributes provided as alternating keys and values
+func (l *Logger) logTrace(ctx context.Context, msg string, attrs []any) {
+	if !l.Enabled(ctx, LevelTrace) {
 		return
 	}
-	pc, file, line, _ := runtime.Caller(1)
-	fn := runtime.FuncForPC(pc).Name()
+
+	// Skip logTrace and Trace, TraceContext, Tracef or TracefContext
+	pc := l.callerPC(2)
 
 	userAttrs := normalizeAttrs(attrs...)
 
@@ -504,19 +965,17 @@ func (l *Logger) Trace(msg string, attrs ...any) {
 		}
 	}
 
-	custom := []slog.Attr{
-		slog.String("trace", string(debug.Stack())),
-		slog.String("source", fmt.Sprintf("%s:%d (%s)", file, line, fn)),
-	}
-
 	rec := slog.NewRecord(timeNow(), LevelTrace, msg, pc)
-	rec.AddAttrs(append(custom, filtered...)...)
+	rec.AddAttrs(append([]slog.Attr{callerSource(pc)}, filtered...)...)
+	rec.AddAttrs(slog.Any(StackKey, l.recordStack(rec, 2)))
 
-	_ = l.Handler().Handle(context.Background(), rec)
+	_ = l.Handler().Handle(ctx, rec)
 }
 
-// Fatal logs the message and exits the program with status 1.
-// This should be used for critical errors that require immediate termination.
+// Fatal logs the message and exits the program.
+// Before exiting, the logger is closed so queued records are written and files
+// are flushed, and the exit hooks run. The exit code is 1 unless changed with
+// SetExitCode. This should be used for critical errors that require immediate termination.
 //
 // Parameters:
 //   - msg: The message to log
@@ -524,15 +983,80 @@ func (l *Logger) Trace(msg string, attrs ...any) {
 //     provided as alternating keys and values
 //
 // Returns:
-//   - None: This function does not return as it calls os.Exit(1)
+//   - None: This function does not return as it calls os.Exit
 func (l *Logger) Fatal(msg string, attrs ...any) {
-	if !l.Enabled(context.Background(), LevelFatal) {
-		osExit(1) // Still exit even if logging is disabled
+	l.logTerminal(context.Background(), LevelFatal, msg, attrs)
+	l.shutdown()
+	osExit(l.exitCode())
+}
+
+// FatalContext logs like Fatal with the given context and exits the program.
+// The context is passed to the context extractors.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - attrs: Additional attributes to include with the log entry,
+//     provided as alternating keys and values
+//
+// Returns:
+//   - None: This function does not return as it calls os.Exit
+func (l *Logger) FatalContext(ctx context.Context, msg string, attrs ...any) {
+	l.logTerminal(ctx, LevelFatal, msg, attrs)
+	l.shutdown()
+	osExit(l.exitCode())
+}
+
+// Panic logs the message and then panics with it.
+// It flushes the logger and runs the exit hooks first, so the record is written
+// even if the panic terminates the program. Unlike Fatal, it does not close the
+// logger, which keeps working if the panic is recovered.
+//
+// Parameters:
+//   - msg: The message to log
+//   - attrs: Additional attributes to include with the log entry,
+//     provided as alternating keys and values
+//
+// Returns:
+//   - None: This function does not return as it panics with msg
+func (l *Logger) Panic(msg string, attrs ...any) {
+	l.logTerminal(context.Background(), LevelPanic, msg, attrs)
+	l.flushBeforePanic()
+	panic(msg)
+}
+
+// PanicContext logs like Panic with the given context and then panics with the message.
+// The context is passed to the context extractors.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - msg: The message to log
+//   - attrs: Additional attributes to include with the log entry,
+//     provided as alternating keys and values
+//
+// Returns:
+//   - None: This function does not return as it panics with msg
+func (l *Logger) PanicContext(ctx context.Context, msg string, attrs ...any) {
+	l.logTerminal(ctx, LevelPanic, msg, attrs)
+	l.flushBeforePanic()
+	panic(msg)
+}
+
+// logTerminal logs the record of the Fatal and Panic methods with the caller's
+// source and, if enabled, a stack trace.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The level of the record (LevelFatal or LevelPanic)
+//   - msg: The message to log
+//   - attrs: Additional attributes provided as alternating keys and values
+func (l *Logger) logTerminal(ctx context.Context, level slog.Level, msg string, attrs []any) {
+	if !l.Enabled(ctx, level) {
 		return
 	}
 
-	pc, file, line, _ := runtime.Caller(1)
-	fn := runtime.FuncForPC(pc).Name()
+	// Skip logTerminal and the Fatal or Panic method
+	pc := l.callerPC(2)
 
 	userAttrs := normalizeAttrs(attrs...)
 
@@ -543,14 +1067,13 @@ func (l *Logger) Fatal(msg string, attrs ...any) {
 		}
 	}
 
-	custom := []slog.Attr{
-		slog.String("source", fmt.Sprintf("%s:%d (%s)", file, line, fn)),
-	}
+	rec := slog.NewRecord(timeNow(), level, msg, pc)
+	rec.AddAttrs(append([]slog.Attr{callerSource(pc)}, filtered...)...)
 
 	// Check if this specific logger has stack traces enabled
 	includeStackTracesForThisLogger := false
-	if l.ctx != nil {
-		includeStackTracesForThisLogger = l.ctx.includeStackTraces
+	if cfg := l.config(); cfg != nil {
+		includeStackTracesForThisLogger = cfg.includeStackTraces
 	} else {
 		// Fall back to global setting for backward compatibility
 		mu.RLock()
@@ -558,15 +1081,11 @@ func (l *Logger) Fatal(msg string, attrs ...any) {
 		mu.RUnlock()
 	}
 
-	if includeStackTracesForThisLogger {
-		custom = append(custom, slog.String("trace", string(debug.Stack())))
+	if includeStackTracesForThisLogger || l.stackAt(level) {
+		rec.AddAttrs(slog.Any(StackKey, l.recordStack(rec, 2)))
 	}
 
-	rec := slog.NewRecord(timeNow(), LevelFatal, msg, pc)
-	rec.AddAttrs(append(custom, filtered...)...)
-
-	_ = l.Handler().Handle(context.Background(), rec)
-	osExit(1)
+	_ = l.Handler().Handle(ctx, rec)
 }
 
 // normalizeAttrs normalizes the attributes passed to the logger.
@@ -629,6 +1148,6 @@ func timeNow() time.Time {
 func SetFileHandlerForTesting(w io.Writer) LoggerOption {
 	return func(ctx *loggerContext) {
 		// Add the provided writer as a file output
-		ctx.outputs = append(ctx.outputs, w)
+		ctx.outputs = append(ctx.outputs, newOutput(w))
 	}
 }
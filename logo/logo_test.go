@@ -3,6 +3,7 @@ package logo
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -923,3 +924,88 @@ func TestAddConsoleOutput(t *testing.T) {
 		}
 	})
 }
+
+// TestNewLoggerE tests creating a logger with options that can fail.
+// It verifies that all failing options are reported and that valid options succeed.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNewLoggerE(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	t.Run("valid options", func(t *testing.T) {
+		var buf bytes.Buffer
+		l, err := NewLoggerE(SetConsoleOutput(&buf), DisableColors())
+		if err != nil {
+			t.Fatalf("NewLoggerE() error = %v", err)
+		}
+
+		l.Info("configured")
+		if !strings.Contains(buf.String(), "msg=configured") {
+			t.Errorf("Logger from NewLoggerE() did not log: %q", buf.String())
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "app.log")
+		blocker := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(blocker, nil, 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+
+		l, err := NewLoggerE(
+			AddFileOutput(logFile, -1, 3, -30, false),
+			AddFileOutput(filepath.Join(blocker, "app.log"), 10, 3, 30, false),
+			UseJSON(false),
+			UseCustomHandler(slog.NewTextHandler(&bytes.Buffer{}, nil)),
+		)
+
+		if l != nil {
+			t.Error("NewLoggerE() should not return a logger when an option fails")
+		}
+
+		if !errors.Is(err, ErrInvalidOption) {
+			t.Fatalf("NewLoggerE() error = %v, want ErrInvalidOption", err)
+		}
+
+		for _, want := range []string{"negative maxSize -1", "negative maxAge -30", "creating log directory", "UseJSON has no effect"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("NewLoggerE() error %q does not mention %q", err, want)
+			}
+		}
+
+		if strings.Contains(err.Error(), "maxBackups") {
+			t.Errorf("NewLoggerE() error %q reports a valid setting", err)
+		}
+	})
+}
+
+// TestInitE tests initializing the global logger with options that can fail.
+// It verifies that the global logger is only replaced when all options succeed.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestInitE(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	if err := InitE(SetConsoleOutput(&buf), DisableColors()); err != nil {
+		t.Fatalf("InitE() error = %v", err)
+	}
+	previous := L()
+
+	if err := InitE(EnableAsync(-1, Block)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("InitE() error = %v, want ErrInvalidOption", err)
+	}
+
+	if L() != previous {
+		t.Error("InitE() replaced the global logger although an option failed")
+	}
+
+	// NewLogger still creates a logger and skips the failing option
+	if l := NewLogger(SetConsoleOutput(&buf), EnableAsync(-1, Block)); l == nil || l.ctx.async != nil {
+		t.Error("NewLogger() should skip the failing option")
+	}
+}
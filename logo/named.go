@@ -0,0 +1,470 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains named loggers and the registry of level overrides which
+// allows subsystems such as "db" or "cache.redis" to log at their own level.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LoggerKey is the key of the attribute holding the name of a named logger.
+const LoggerKey = "logger"
+
+// Named returns a child of the global logger with the given name.
+// See Logger.Named for details.
+//
+// Parameters:
+//   - name: The name of the subsystem (e.g., "db" or "cache.redis")
+//
+// Returns:
+//   - *Logger: The named child logger, or nil if the global logger has not been initialized
+func Named(name string) *Logger {
+	return L().Named(name)
+}
+
+// Named returns a child logger for a subsystem. The child uses the outputs and
+// settings of its parent and adds the name as a "logger" attribute to every
+// record, at the top level even if the parent has open groups. Names are
+// hierarchical: calling Named on a named logger appends the name with a dot,
+// so L().Named("cache").Named("redis") is named "cache.redis".
+//
+// The level of a named logger is taken from the most specific override set
+// with SetLevelFor, SetNamedLevel or SetLoggerLevel: an override for the exact
+// name wins over "cache.redis.*", which wins over "cache.*" and "*". Without
+// a matching override the logger follows the level of its root logger.
+//
+// Parameters:
+//   - name: The name of the subsystem, relative to the name of this logger
+//
+// Returns:
+//   - *Logger: The named child logger
+func (l *Logger) Named(name string) *Logger {
+	if l == nil || name == "" {
+		return l
+	}
+
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+
+	// The name is added to the root handler, outside of any group, and the
+	// attributes and groups of this logger are applied again after it
+	origin := l.Handler()
+	if l.root != nil {
+		origin = l.root
+	}
+	handler := origin.WithAttrs([]slog.Attr{slog.String(LoggerKey, fullName)})
+	if l.root != nil {
+		levels := &l.root.state.levels
+		handler = withLeveler(handler, func(parent slog.Leveler) slog.Leveler {
+			return &namedLeveler{levels: levels, name: fullName, parent: parent}
+		})
+		for _, op := range l.ops {
+			handler = op(slog.New(handler)).Handler()
+		}
+	}
+
+	return &Logger{
+		Logger:     slog.New(handler),
+		ctx:        l.ctx,
+		root:       l.root,
+		name:       fullName,
+		ops:        l.ops,
+		callerSkip: l.callerSkip,
+	}
+}
+
+// Name returns the name of a logger created with Named.
+//
+// Returns:
+//   - string: The full dotted name, or an empty string for loggers without a name
+func (l *Logger) Name() string {
+	if l == nil {
+		return ""
+	}
+	return l.name
+}
+
+// SetLevelFor sets the level of the named loggers matching a pattern.
+// The pattern is either a logger name such as "cache.redis", a name followed
+// by ".*" for the logger and all loggers below it, such as "cache.*", or "*"
+// for all named loggers. Options applied later for the same pattern win.
+//
+// Parameters:
+//   - pattern: The name or pattern of the loggers
+//   - level: The minimum log level for the matching loggers
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the level of named loggers
+func SetLevelFor(pattern string, level slog.Level) LoggerOption {
+	return func(ctx *loggerContext) {
+		if err := checkLevelPattern(pattern); err != nil {
+			ctx.addError(fmt.Errorf("%w: SetLevelFor: %v", ErrInvalidOption, err))
+			return
+		}
+		if ctx.namedLevels == nil {
+			ctx.namedLevels = make(map[string]slog.Level)
+		}
+		ctx.namedLevels[pattern] = level
+	}
+}
+
+// SetNamedLevel sets the level of the named loggers matching a pattern at runtime.
+// Patterns are described at SetLevelFor. The change applies immediately to all
+// named loggers derived from the logger, including ones created before the call.
+//
+// Parameters:
+//   - logger: The logger whose named children are configured (can be nil for global logger)
+//   - pattern: The name or pattern of the loggers
+//   - level: The minimum log level for the matching loggers
+//
+// Returns:
+//   - error: An error if the pattern is invalid or the logger does not support named levels
+func SetNamedLevel(logger *Logger, pattern string, level slog.Level) error {
+	levels, err := namedLevelsOf(logger)
+	if err != nil {
+		return err
+	}
+	if err := checkLevelPattern(pattern); err != nil {
+		return err
+	}
+
+	levels.set(pattern, level)
+	return nil
+}
+
+// ClearNamedLevel removes the level override for a pattern, so the matching
+// named loggers use the next less specific override or the root level again.
+//
+// Parameters:
+//   - logger: The logger whose named children are configured (can be nil for global logger)
+//   - pattern: The name or pattern given to SetNamedLevel or SetLevelFor
+func ClearNamedLevel(logger *Logger, pattern string) {
+	if levels, err := namedLevelsOf(logger); err == nil {
+		levels.remove(pattern)
+	}
+}
+
+// namedLevelsOf returns the level overrides shared by a logger and its named children.
+//
+// Parameters:
+//   - logger: The logger, or nil for the global logger
+//
+// Returns:
+//   - *namedLevels: The level overrides
+//   - error: An error if the logger was not created by NewLogger
+func namedLevelsOf(logger *Logger) (*namedLevels, error) {
+	if logger == nil {
+		logger = L()
+	}
+	if logger == nil || logger.root == nil {
+		return nil, fmt.Errorf("logger does not support named levels")
+	}
+	return &logger.root.state.levels, nil
+}
+
+// checkLevelPattern checks the syntax of a logger name pattern.
+//
+// Parameters:
+//   - pattern: The name or pattern to check
+//
+// Returns:
+//   - error: An error describing the problem, or nil for valid patterns
+func checkLevelPattern(pattern string) error {
+	if pattern == "*" {
+		return nil
+	}
+
+	name := strings.TrimSuffix(pattern, ".*")
+	if name == "" || strings.Contains(name, "*") ||
+		strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return fmt.Errorf(`invalid logger pattern %q, use a name, a name followed by ".*" or "*"`, pattern)
+	}
+	return nil
+}
+
+// namedLevels is the registry of level overrides for named loggers.
+// It is shared by a logger and everything derived from it, and its generation
+// changes whenever a pattern is added or removed, so lookups can be cached.
+type namedLevels struct {
+	mu         sync.RWMutex
+	levels     map[string]*slog.LevelVar
+	generation atomic.Uint64
+}
+
+// lookup returns the most specific override matching a logger name.
+//
+// Parameters:
+//   - name: The full name of the logger
+//
+// Returns:
+//   - *slog.LevelVar: The matching level, or nil if no pattern matches
+func (n *namedLevels) lookup(name string) *slog.LevelVar {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if len(n.levels) == 0 {
+		return nil
+	}
+	if v := n.levels[name]; v != nil {
+		return v
+	}
+
+	// Walk up the hierarchy: "a.b.*", then "a.*", then "*"
+	for prefix := name; prefix != ""; {
+		if v := n.levels[prefix+".*"]; v != nil {
+			return v
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return n.levels["*"]
+}
+
+// get returns the level of a pattern.
+//
+// Parameters:
+//   - pattern: The name or pattern
+//
+// Returns:
+//   - *slog.LevelVar: The level of the pattern, or nil if it has no override
+func (n *namedLevels) get(pattern string) *slog.LevelVar {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.levels[pattern]
+}
+
+// levelVar returns the level of a pattern, adding the pattern if necessary.
+//
+// Parameters:
+//   - pattern: The name or pattern
+//   - initial: The level of a newly added pattern
+//
+// Returns:
+//   - *slog.LevelVar: The level of the pattern
+//   - bool: True if the pattern was added
+func (n *namedLevels) levelVar(pattern string, initial slog.Level) (*slog.LevelVar, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if v := n.levels[pattern]; v != nil {
+		return v, false
+	}
+
+	v := new(slog.LevelVar)
+	v.Set(initial)
+	if n.levels == nil {
+		n.levels = make(map[string]*slog.LevelVar)
+	}
+	n.levels[pattern] = v
+	n.generation.Add(1)
+	return v, true
+}
+
+// set sets the level of a pattern.
+//
+// Parameters:
+//   - pattern: The name or pattern
+//   - level: The new level
+func (n *namedLevels) set(pattern string, level slog.Level) {
+	v, _ := n.levelVar(pattern, level)
+	v.Set(level)
+}
+
+// remove removes the override of a pattern.
+//
+// Parameters:
+//   - pattern: The name or pattern
+func (n *namedLevels) remove(pattern string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.levels[pattern]; ok {
+		delete(n.levels, pattern)
+		n.generation.Add(1)
+	}
+}
+
+// removeVar removes the override of a pattern if it is still the given level
+// variable, so an override configured again meanwhile is kept.
+//
+// Parameters:
+//   - pattern: The name or pattern
+//   - v: The level variable to remove
+func (n *namedLevels) removeVar(pattern string, v *slog.LevelVar) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.levels[pattern] == v {
+		delete(n.levels, pattern)
+		n.generation.Add(1)
+	}
+}
+
+// reset replaces all overrides with the configured ones.
+//
+// Parameters:
+//   - levels: The levels by pattern
+func (n *namedLevels) reset(levels map[string]slog.Level) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.levels = make(map[string]*slog.LevelVar, len(levels))
+	for pattern, level := range levels {
+		v := new(slog.LevelVar)
+		v.Set(level)
+		n.levels[pattern] = v
+	}
+	n.generation.Add(1)
+}
+
+// namedLeveler is the slog.Leveler of a named logger. It caches the matching
+// override until the registry changes.
+type namedLeveler struct {
+	levels *namedLevels
+	name   string
+	parent slog.Leveler
+	cached atomic.Pointer[namedLookup]
+}
+
+// namedLookup is the cached result of a registry lookup.
+type namedLookup struct {
+	generation uint64
+	level      *slog.LevelVar
+}
+
+// Level implements slog.Leveler interface.
+// It returns the level of the most specific override, or the parent level.
+//
+// Returns:
+//   - slog.Level: The minimum level of the named logger
+func (n *namedLeveler) Level() slog.Level {
+	if v := n.override(); v != nil {
+		return v.Level()
+	}
+	if n.parent != nil {
+		return n.parent.Level()
+	}
+	return levelAll
+}
+
+// override returns the override matching the name, using the cache if the
+// registry has not changed since the last lookup.
+//
+// Returns:
+//   - *slog.LevelVar: The matching level, or nil if no pattern matches
+func (n *namedLeveler) override() *slog.LevelVar {
+	generation := n.levels.generation.Load()
+	if c := n.cached.Load(); c != nil && c.generation == generation {
+		return c.level
+	}
+
+	v := n.levels.lookup(n.name)
+	n.cached.Store(&namedLookup{generation: generation, level: v})
+	return v
+}
+
+// levelOverrider is implemented by handlers whose logger-wide level can be
+// replaced in a derived handler, so a named logger can log below the level of
+// its root logger.
+type levelOverrider interface {
+	withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler
+}
+
+// withLeveler derives a handler whose logger-wide level is wrap applied to the
+// current one. Handlers that do not support this are wrapped in a handler that
+// applies the new level in addition to their own.
+//
+// Parameters:
+//   - h: The handler to derive from
+//   - wrap: Returns the new level given the current one, which may be nil
+//
+// Returns:
+//   - slog.Handler: The derived handler
+func withLeveler(h slog.Handler, wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	if o, ok := h.(levelOverrider); ok {
+		return o.withLeveler(wrap)
+	}
+	return &leveledHandler{next: h, level: wrap(nil)}
+}
+
+// leveledHandler applies an additional minimum level to a handler.
+type leveledHandler struct {
+	next  slog.Handler
+	level slog.Leveler
+}
+
+// Enabled implements slog.Handler interface.
+// It reports whether the level passes the additional level, or the level set
+// for the context with WithLevel, and the wrapped handler.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *leveledHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= minLevel(ctx, h.level) && h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler interface.
+// It passes the record to the wrapped handler.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Any error returned by the wrapped handler
+func (h *leveledHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a handler applying the same level to the wrapped handler with the attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *leveledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &leveledHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler applying the same level to the wrapped handler with the group.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *leveledHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &leveledHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// withLeveler replaces the additional level.
+//
+// Parameters:
+//   - wrap: Returns the new level given the current one
+//
+// Returns:
+//   - slog.Handler: A handler applying the new level to the wrapped handler
+func (h *leveledHandler) withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	return &leveledHandler{next: h.next, level: wrap(h.level)}
+}
@@ -0,0 +1,216 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for named loggers and their level overrides.
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestNamed tests that named loggers add their name to text and JSON records
+// and that nested names are joined with dots.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNamed(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text, jsonBuf bytes.Buffer
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddWriterOutput(&jsonBuf, OutputFormat(FormatJSON)),
+	)
+
+	redis := testLogger.Named("cache").Named("redis")
+	if redis.Name() != "cache.redis" {
+		t.Errorf("Name() = %q, want %q", redis.Name(), "cache.redis")
+	}
+	redis.Info("connected")
+
+	if got := text.String(); !strings.Contains(got, "logger=cache.redis") || strings.Count(got, "logger=") != 1 {
+		t.Errorf("Text output = %q, want a single logger=cache.redis", got)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	if entry[LoggerKey] != "cache.redis" {
+		t.Errorf("JSON logger = %v, want %q", entry[LoggerKey], "cache.redis")
+	}
+
+	if testLogger.Named("") != testLogger {
+		t.Error("Named(\"\") should return the logger itself")
+	}
+}
+
+// TestNamed_WithGroup tests that the name of a logger created within a group
+// is written at the top level, while the attributes and groups of the parent
+// still apply to the record.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNamed_WithGroup(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text, jsonBuf bytes.Buffer
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddWriterOutput(&jsonBuf, OutputFormat(FormatJSON)),
+	)
+
+	testLogger.With("app", "api").WithGroup("g").With("k", "v").Named("db").Named("pool").Info("hi", "n", 1)
+
+	got := text.String()
+	for _, want := range []string{" app=api ", " g.k=v ", " g.n=1", " logger=db.pool"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Text output = %q, want %q", got, want)
+		}
+	}
+	if strings.Contains(got, "g.logger") {
+		t.Errorf("Text output = %q, want the name outside of the group", got)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	group, _ := entry["g"].(map[string]any)
+	if entry[LoggerKey] != "db.pool" || entry["app"] != "api" || group["k"] != "v" || group[LoggerKey] != nil {
+		t.Errorf("JSON output = %v, want logger=db.pool at the top level", entry)
+	}
+}
+
+// TestNamed_Levels tests the level overrides of named loggers.
+// It verifies the precedence of exact names and patterns, that named loggers
+// can log below the root level, and that the root level is unaffected.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNamed_Levels(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		SetLevelFor("cache.*", slog.LevelDebug),
+		SetLevelFor("cache.redis", slog.LevelError),
+	)
+
+	cache := testLogger.Named("cache")
+	memcache := cache.Named("memcache")
+	redis := cache.Named("redis")
+	db := testLogger.Named("db")
+
+	tests := []struct {
+		logger *Logger
+		want   slog.Level
+	}{
+		{testLogger, slog.LevelInfo},
+		{cache, slog.LevelDebug},
+		{memcache, slog.LevelDebug},
+		{redis, slog.LevelError},
+		{db, slog.LevelInfo},
+	}
+	for _, tt := range tests {
+		if got := GetCurrentLevel(tt.logger); got != tt.want {
+			t.Errorf("GetCurrentLevel(%q) = %v, want %v", tt.logger.Name(), got, tt.want)
+		}
+	}
+
+	memcache.Debug("memcache debug")
+	redis.Warn("redis warn")
+	testLogger.Debug("root debug")
+
+	got := w.String()
+	if !strings.Contains(got, "memcache debug") {
+		t.Errorf("Pattern override did not enable DEBUG, output %q", got)
+	}
+	if strings.Contains(got, "redis warn") || strings.Contains(got, "root debug") {
+		t.Errorf("Records below the effective level were written, output %q", got)
+	}
+
+	// Runtime changes apply to existing named loggers
+	if err := SetNamedLevel(testLogger, "*", LevelTrace); err != nil {
+		t.Fatalf("SetNamedLevel() error = %v", err)
+	}
+	if !IsLevelEnabled(LevelTrace, db) || IsLevelEnabled(LevelTrace, testLogger) {
+		t.Error("SetNamedLevel(\"*\") should enable TRACE for named loggers only")
+	}
+
+	SetLoggerLevel(redis, slog.LevelWarn)
+	redis.Warn("redis warn again")
+	if !strings.Contains(w.String(), "redis warn again") || GetCurrentLevel(testLogger) != slog.LevelInfo {
+		t.Error("SetLoggerLevel() on a named logger should only change its own level")
+	}
+
+	ClearNamedLevel(testLogger, "cache.*")
+	if got := GetCurrentLevel(memcache); got != LevelTrace {
+		t.Errorf("After ClearNamedLevel() level = %v, want the \"*\" level %v", got, LevelTrace)
+	}
+
+	if err := SetNamedLevel(testLogger, "ca*che", slog.LevelDebug); err == nil {
+		t.Error("SetNamedLevel() with an invalid pattern should fail")
+	}
+	if _, err := NewLoggerE(SetLevelFor(".*", slog.LevelDebug)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("SetLevelFor() with an invalid pattern error = %v, want ErrInvalidOption", err)
+	}
+}
+
+// TestNamed_LevelHandler tests changing the level of named loggers over HTTP.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestNamed_LevelHandler(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	testLogger := NewLogger(DisableConsole())
+	redis := testLogger.Named("cache.redis")
+	h := LevelHandler(testLogger)
+
+	if code, resp := serveLevel(t, h, http.MethodGet, "/?logger=cache.redis", "", ""); code != http.StatusOK || resp.Level != "INFO" {
+		t.Errorf("GET named logger = %d %+v, want 200 INFO", code, resp)
+	}
+
+	if code, resp := serveLevel(t, h, http.MethodPut, "/?logger=cache.*&level=debug", "", ""); code != http.StatusOK || resp.Level != "DEBUG" {
+		t.Errorf("PUT pattern = %d %+v, want 200 DEBUG", code, resp)
+	}
+	if !IsLevelEnabled(slog.LevelDebug, redis) || IsLevelEnabled(slog.LevelDebug, testLogger) {
+		t.Error("Pattern level change should only apply to the matching named loggers")
+	}
+}
+
+// TestWithConfig_Loggers tests the level overrides of named loggers in a configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestWithConfig_Loggers(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	testLogger, err := NewLoggerE(WithConfig(Config{Loggers: map[string]string{"db": "warn"}}))
+	if err != nil {
+		t.Fatalf("NewLoggerE() error = %v", err)
+	}
+	if got := GetCurrentLevel(testLogger.Named("db")); got != slog.LevelWarn {
+		t.Errorf("Configured level = %v, want %v", got, slog.LevelWarn)
+	}
+
+	_, err = NewLoggerE(WithConfig(Config{Loggers: map[string]string{"db": "loud"}}))
+	if err == nil || !strings.Contains(err.Error(), "loggers[db]") {
+		t.Errorf("Invalid logger level error = %v, want it to name loggers[db]", err)
+	}
+}
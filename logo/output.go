@@ -0,0 +1,213 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the per-output configuration which lets every sink of a
+// logger carry its own format, minimum level and source settings.
+package logo
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+)
+
+// Format selects how an individual output renders log records.
+type Format int
+
+const (
+	// FormatDefault uses the logger-wide format selected with UseJSON
+	FormatDefault Format = iota
+
+	// FormatText renders records as key=value text
+	FormatText
+
+	// FormatJSON renders records as compact JSON objects
+	FormatJSON
+
+	// FormatPrettyJSON renders records as indented JSON objects
+	FormatPrettyJSON
+)
+
+// levelAll is the output level used when an output has no minimum level of
+// its own, so that only the logger-wide level filters records.
+const levelAll = slog.Level(math.MinInt)
+
+// String returns the name of the format as used in configuration.
+//
+// Returns:
+//   - string: The format name, or "default" for FormatDefault
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	case FormatPrettyJSON:
+		return "json-pretty"
+	default:
+		return "default"
+	}
+}
+
+// ParseFormat converts a format name as returned by Format.String to a Format.
+// Names are case-insensitive, and "pretty-json" is accepted as an alias of "json-pretty".
+//
+// Parameters:
+//   - name: The format name (e.g., "text", "json")
+//
+// Returns:
+//   - Format: The parsed format
+//   - error: An error if the name is not a known format
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "default":
+		return FormatDefault, nil
+	case "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "json-pretty", "pretty-json":
+		return FormatPrettyJSON, nil
+	default:
+		return FormatDefault, fmt.Errorf("unknown format %q", name)
+	}
+}
+
+// output describes a single log sink together with its own rendering settings.
+type output struct {
+	w          io.Writer
+	newHandler func(*slog.HandlerOptions) slog.Handler
+	console    bool
+	format     Format
+	level      slog.Level
+	hasLevel   bool
+	source     *bool
+
+	// channelOpts configure the delivery of channel outputs
+	channelOpts []ChannelOption
+}
+
+// OutputOption is a functional option type for configuring a single output.
+// It can be passed to AddFileOutput, AddChannelOutput, AddConsoleOutput and
+// AddWriterOutput to override the logger-wide settings for that output only.
+type OutputOption func(*output)
+
+// OutputFormat sets the format used by a single output.
+//
+// Parameters:
+//   - format: The format for this output (e.g., FormatText, FormatJSON)
+//
+// Returns:
+//   - OutputOption: A function that can be passed to an Add*Output option
+func OutputFormat(format Format) OutputOption {
+	return func(o *output) {
+		o.format = format
+	}
+}
+
+// OutputLevel sets the minimum level of a single output.
+// The logger-wide level still applies first, so an output can only be
+// stricter than the logger it belongs to.
+//
+// Parameters:
+//   - level: The minimum log level written to this output
+//
+// Returns:
+//   - OutputOption: A function that can be passed to an Add*Output option
+func OutputLevel(level slog.Level) OutputOption {
+	return func(o *output) {
+		o.level = level
+		o.hasLevel = true
+	}
+}
+
+// OutputSource enables or disables source information for a single output,
+// regardless of the logger-wide AddSource setting.
+//
+// Parameters:
+//   - enabled: Whether this output should include source file and line information
+//
+// Returns:
+//   - OutputOption: A function that can be passed to an Add*Output option
+func OutputSource(enabled bool) OutputOption {
+	return func(o *output) {
+		o.source = &enabled
+	}
+}
+
+// newOutput creates an output for the given writer and applies the options to it.
+//
+// Parameters:
+//   - w: The writer that receives the formatted log entries
+//   - opts: Options overriding the logger-wide settings for this output
+//
+// Returns:
+//   - *output: The configured output
+func newOutput(w io.Writer, opts ...OutputOption) *output {
+	o := &output{w: w}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// handler creates the slog.Handler that renders records for this output.
+// Settings that are not overridden on the output are taken from the logger context.
+//
+// Parameters:
+//   - ctx: The logger context providing the logger-wide defaults
+//
+// Returns:
+//   - slog.Handler: A handler writing to this output in its configured format
+func (o *output) handler(ctx *loggerContext) slog.Handler {
+	format := o.format
+	if format == FormatDefault {
+		format = FormatText
+		if ctx.useJSONFormat {
+			format = FormatJSON
+			if ctx.jsonPretty {
+				format = FormatPrettyJSON
+			}
+		}
+	}
+
+	level := levelAll
+	if o.hasLevel {
+		level = o.level
+	}
+
+	addSource := ctx.includeSource
+	if o.source != nil {
+		addSource = *o.source
+	}
+
+	handlerOptions := &slog.HandlerOptions{
+		Level:     level,
+		AddSource: addSource,
+	}
+
+	// Outputs that are not writers provide their own handler and ignore the format
+	if o.newHandler != nil {
+		return o.newHandler(handlerOptions)
+	}
+
+	switch format {
+	case FormatJSON:
+		return NewJSONHandler(o.w, handlerOptions, false)
+	case FormatPrettyJSON:
+		return NewJSONHandler(o.w, handlerOptions, true)
+	default:
+		if o.console {
+			// Only use styled rendering for text format
+			theme := DefaultConsoleTheme()
+			if ctx.consoleTheme != nil {
+				theme = *ctx.consoleTheme
+			}
+			colors, force := consoleColors(ctx, os.LookupEnv)
+			return newConsoleHandler(o.w, handlerOptions, theme, colors, force)
+		}
+		return NewCustomTextHandler(o.w, handlerOptions)
+	}
+}
@@ -0,0 +1,234 @@
+package logo
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFormat_String tests the String method of Format.
+// It verifies that every format has the name used in configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatDefault, "default"},
+		{FormatText, "text"},
+		{FormatJSON, "json"},
+		{FormatPrettyJSON, "json-pretty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.format.String(); got != tt.want {
+				t.Errorf("Format(%d).String() = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOutputOptions tests the functional options used to configure a single output.
+// It verifies that each option correctly modifies the output configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestOutputOptions(t *testing.T) {
+	var buf bytes.Buffer
+
+	out := newOutput(&buf,
+		OutputFormat(FormatJSON),
+		OutputLevel(slog.LevelWarn),
+		OutputSource(true),
+	)
+
+	if out.w != &buf {
+		t.Error("newOutput() didn't set the writer")
+	}
+
+	if out.format != FormatJSON {
+		t.Errorf("OutputFormat() didn't set the format, got %v", out.format)
+	}
+
+	if !out.hasLevel || out.level != slog.LevelWarn {
+		t.Errorf("OutputLevel() didn't set the level, got %v", out.level)
+	}
+
+	if out.source == nil || !*out.source {
+		t.Error("OutputSource(true) didn't enable source")
+	}
+
+	// Without options the output inherits everything from the logger
+	out = newOutput(&buf)
+	if out.format != FormatDefault || out.hasLevel || out.source != nil {
+		t.Error("newOutput() without options should inherit the logger-wide settings")
+	}
+}
+
+// TestOutput_Handler tests the handler created for an output.
+// It verifies that the logger-wide format is used unless the output overrides it.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestOutput_Handler(t *testing.T) {
+	var buf bytes.Buffer
+
+	tests := []struct {
+		name       string
+		ctx        *loggerContext
+		opts       []OutputOption
+		wantJSON   bool
+		wantPretty bool
+	}{
+		{
+			name: "inherits text",
+			ctx:  &loggerContext{},
+		},
+		{
+			name:     "inherits json",
+			ctx:      &loggerContext{useJSONFormat: true},
+			wantJSON: true,
+		},
+		{
+			name:       "inherits pretty json",
+			ctx:        &loggerContext{useJSONFormat: true, jsonPretty: true},
+			wantJSON:   true,
+			wantPretty: true,
+		},
+		{
+			name:     "overrides text with json",
+			ctx:      &loggerContext{},
+			opts:     []OutputOption{OutputFormat(FormatJSON)},
+			wantJSON: true,
+		},
+		{
+			name: "overrides json with text",
+			ctx:  &loggerContext{useJSONFormat: true},
+			opts: []OutputOption{OutputFormat(FormatText)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newOutput(&buf, tt.opts...).handler(tt.ctx)
+
+			jsonHandler, isJSON := handler.(*JSONHandler)
+			if isJSON != tt.wantJSON {
+				t.Fatalf("handler type = %T, want JSON: %v", handler, tt.wantJSON)
+			}
+
+			if isJSON && jsonHandler.prettyPrint != tt.wantPretty {
+				t.Errorf("prettyPrint = %v, want %v", jsonHandler.prettyPrint, tt.wantPretty)
+			}
+		})
+	}
+}
+
+// TestPerOutputFormatsAndLevels tests a logger with differently configured outputs.
+// It verifies that a single logger can write text and JSON at different levels.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPerOutputFormatsAndLevels(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "logger-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	logFile := filepath.Join(tempDir, "json.log")
+
+	var consoleBuf bytes.Buffer
+	ch := make(chan string, 10)
+
+	testLogger := NewLogger(
+		SetLevel(slog.LevelDebug),
+		DisableColors(),
+		SetConsoleOutput(&consoleBuf),
+		AddConsoleOutput(OutputLevel(slog.LevelInfo)),
+		AddFileOutput(logFile, 10, 3, 30, false, OutputFormat(FormatJSON)),
+		AddChannelOutput(ch, OutputLevel(slog.LevelWarn)),
+	)
+
+	testLogger.Debug("debug message")
+	testLogger.Info("info message", "key", "value")
+	testLogger.Warn("warn message")
+
+	if err := testLogger.Close(); err != nil {
+		t.Errorf("Failed to close logger: %v", err)
+	}
+
+	// Console: text at INFO and above
+	console := consoleBuf.String()
+	if strings.Contains(console, "debug message") {
+		t.Errorf("Console output should not contain the debug message: %q", console)
+	}
+	if !strings.Contains(console, "msg=info message") || !strings.Contains(console, "key=value") {
+		t.Errorf("Console output should contain the info message as text: %q", console)
+	}
+
+	// File: JSON at DEBUG and above
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Log file contains %d lines, want 3: %q", len(lines), content)
+	}
+	for _, line := range lines {
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+			t.Errorf("Log file line is not JSON: %q", line)
+		}
+	}
+
+	// Channel: text at WARN and above
+	select {
+	case msg := <-ch:
+		if !strings.Contains(msg, "msg=warn message") {
+			t.Errorf("Channel message %q should be the warn message", msg)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Error("Timeout waiting for message on channel")
+	}
+	select {
+	case msg := <-ch:
+		t.Errorf("Channel unexpectedly contained extra message: %q", msg)
+	default:
+	}
+}
+
+// TestParseFormat tests the ParseFormat function.
+// It verifies that every format name round-trips through Format.String.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestParseFormat(t *testing.T) {
+	for _, format := range []Format{FormatDefault, FormatText, FormatJSON, FormatPrettyJSON} {
+		got, err := ParseFormat(format.String())
+		if err != nil || got != format {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v", format.String(), got, err, format)
+		}
+	}
+
+	if got, err := ParseFormat("Pretty-JSON"); err != nil || got != FormatPrettyJSON {
+		t.Errorf("ParseFormat(%q) = %v, %v; want %v", "Pretty-JSON", got, err, FormatPrettyJSON)
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(\"xml\") should return an error")
+	}
+}
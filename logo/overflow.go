@@ -0,0 +1,264 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the overflow policies used by channel outputs when their
+// channel is full, together with the accounting of dropped messages.
+package logo
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy selects what a channel output does when its channel is full.
+type OverflowPolicy int
+
+const (
+	// DropNewest discards the message that does not fit into the channel
+	DropNewest OverflowPolicy = iota
+
+	// DropOldest discards the oldest buffered message to make room for the new one.
+	// Unbuffered channels fall back to DropNewest.
+	DropOldest
+
+	// Block waits until the channel has room for the message
+	Block
+
+	// BlockWithTimeout waits for room up to the block timeout and then discards the message
+	BlockWithTimeout
+)
+
+// defaultBlockTimeout is the time BlockWithTimeout waits when no timeout was configured.
+const defaultBlockTimeout = 100 * time.Millisecond
+
+// String returns the name of the overflow policy as used in configuration.
+//
+// Returns:
+//   - string: The policy name (e.g., "drop-newest", "block")
+func (p OverflowPolicy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop-oldest"
+	case Block:
+		return "block"
+	case BlockWithTimeout:
+		return "block-with-timeout"
+	default:
+		return "drop-newest"
+	}
+}
+
+// ChannelOption is a functional option type for configuring how a channel
+// writer or handler delivers messages to its channel.
+type ChannelOption func(*channelConfig)
+
+// channelConfig holds the delivery settings of a channel output.
+type channelConfig struct {
+	policy  OverflowPolicy
+	timeout time.Duration
+}
+
+// WithOverflowPolicy sets the policy applied when the channel is full.
+// The default policy is DropNewest.
+//
+// Parameters:
+//   - policy: The overflow policy to use
+//
+// Returns:
+//   - ChannelOption: A function that can be passed to NewChannelWriter or NewRecordChannelHandler
+func WithOverflowPolicy(policy OverflowPolicy) ChannelOption {
+	return func(c *channelConfig) {
+		c.policy = policy
+	}
+}
+
+// WithBlockTimeout sets how long the BlockWithTimeout policy waits for room
+// in the channel before the message is dropped.
+//
+// Parameters:
+//   - timeout: The maximum time to wait for the channel
+//
+// Returns:
+//   - ChannelOption: A function that can be passed to NewChannelWriter or NewRecordChannelHandler
+func WithBlockTimeout(timeout time.Duration) ChannelOption {
+	return func(c *channelConfig) {
+		c.timeout = timeout
+	}
+}
+
+// OutputOverflow sets the overflow policy of a channel output.
+// It only has an effect on outputs added with AddChannelOutput or AddRecordChannelOutput.
+//
+// Parameters:
+//   - policy: The overflow policy to use when the channel is full
+//   - timeout: The maximum wait for BlockWithTimeout, zero for the default
+//
+// Returns:
+//   - OutputOption: A function that can be passed to an Add*Output option
+func OutputOverflow(policy OverflowPolicy, timeout time.Duration) OutputOption {
+	return func(o *output) {
+		o.channelOpts = append(o.channelOpts, WithOverflowPolicy(policy), WithBlockTimeout(timeout))
+	}
+}
+
+// channelSender delivers values to a channel according to an overflow policy
+// and counts the values it had to drop.
+type channelSender[T any] struct {
+	ch      chan T
+	policy  OverflowPolicy
+	timeout time.Duration
+	dropped atomic.Uint64
+}
+
+// newChannelSender creates a sender for the channel and applies the options to it.
+//
+// Parameters:
+//   - ch: The channel to deliver values to
+//   - opts: Options selecting the overflow policy
+//
+// Returns:
+//   - *channelSender[T]: The configured sender
+func newChannelSender[T any](ch chan T, opts ...ChannelOption) *channelSender[T] {
+	cfg := channelConfig{policy: DropNewest}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.timeout <= 0 {
+		cfg.timeout = defaultBlockTimeout
+	}
+
+	return &channelSender[T]{
+		ch:      ch,
+		policy:  cfg.policy,
+		timeout: cfg.timeout,
+	}
+}
+
+// send delivers the value to the channel, applying the overflow policy if the channel is full.
+//
+// Parameters:
+//   - v: The value to deliver
+func (s *channelSender[T]) send(v T) {
+	// Fast path: the channel has room
+	select {
+	case s.ch <- v:
+		return
+	default:
+	}
+
+	switch s.policy {
+	case Block:
+		s.ch <- v
+	case BlockWithTimeout:
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+		select {
+		case s.ch <- v:
+		case <-timer.C:
+			s.dropped.Add(1)
+		}
+	case DropOldest:
+		// An unbuffered channel holds no message that could be discarded
+		if cap(s.ch) == 0 {
+			s.dropped.Add(1)
+			return
+		}
+
+		// Make room by discarding the oldest message, unless a consumer got to it first
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+		default:
+		}
+
+		// Drop the message if another sender took the room in the meantime
+		select {
+		case s.ch <- v:
+		default:
+			s.dropped.Add(1)
+		}
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of values the sender dropped.
+//
+// Returns:
+//   - uint64: The total number of dropped values
+func (s *channelSender[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// droppedCounter is implemented by outputs that count the messages they dropped.
+type droppedCounter interface {
+	Dropped() uint64
+}
+
+// ReportDroppedMessages periodically writes a WARN record with the number of
+// messages that channel outputs dropped since the previous report.
+// The report is written to os.Stderr rather than to the outputs of the logger,
+// whose full channels would drop it too and count it as another dropped message.
+// Nothing is written for intervals without drops. Reporting stops when the
+// logger is closed.
+//
+// Parameters:
+//   - interval: How often to check the channel outputs for dropped messages
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to enable drop reports
+func ReportDroppedMessages(interval time.Duration) LoggerOption {
+	return func(ctx *loggerContext) {
+		if interval < 0 {
+			ctx.addError(fmt.Errorf("%w: ReportDroppedMessages: negative interval %v", ErrInvalidOption, interval))
+			return
+		}
+		ctx.dropReportInterval = interval
+	}
+}
+
+// startDropReporter starts the goroutine that reports dropped messages for a logger.
+// The goroutine is stopped by the closer it registers on the logger context.
+//
+// Parameters:
+//   - ctx: The logger context whose outputs are watched
+//   - interval: How often to report
+func startDropReporter(ctx *loggerContext, interval time.Duration) {
+	counters := ctx.dropCounters
+	done := make(chan struct{})
+	var once sync.Once
+	ctx.closers = append(ctx.closers, func() error {
+		once.Do(func() { close(done) })
+		return nil
+	})
+
+	// Reports bypass the outputs and their overflow policies
+	reporter := slog.New(NewCustomTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var reported uint64
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				var total uint64
+				for _, c := range counters {
+					total += c.Dropped()
+				}
+
+				if total > reported {
+					reporter.Warn("Log messages dropped", "dropped", total-reported, "total_dropped", total)
+					reported = total
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,234 @@
+package logo
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChannelWriter_OverflowPolicies tests the overflow policies of ChannelWriter.
+// It verifies which message survives when writing to a full channel and that drops are counted.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestChannelWriter_OverflowPolicies(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	tests := []struct {
+		name        string
+		opts        []ChannelOption
+		wantMessage string
+	}{
+		{"default drops newest", nil, "old"},
+		{"drop newest", []ChannelOption{WithOverflowPolicy(DropNewest)}, "old"},
+		{"drop oldest", []ChannelOption{WithOverflowPolicy(DropOldest)}, "new"},
+		{"block with timeout", []ChannelOption{WithOverflowPolicy(BlockWithTimeout), WithBlockTimeout(10 * time.Millisecond)}, "old"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan string, 1)
+			writer := NewChannelWriter(ch, tt.opts...)
+
+			writer.Write([]byte("old"))
+			writer.Write([]byte("new"))
+
+			if msg := <-ch; msg != tt.wantMessage {
+				t.Errorf("Channel contained %q, want %q", msg, tt.wantMessage)
+			}
+
+			if writer.Dropped() != 1 {
+				t.Errorf("Dropped() = %d, want 1", writer.Dropped())
+			}
+		})
+	}
+}
+
+// TestChannelWriter_DropOldestUnbuffered tests the DropOldest policy with an
+// unbuffered channel without a reader.
+// It verifies that the write returns instead of retrying and counts the drop.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestChannelWriter_DropOldestUnbuffered(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	writer := NewChannelWriter(make(chan string), WithOverflowPolicy(DropOldest))
+
+	done := make(chan struct{})
+	go func() {
+		writer.Write([]byte("nobody reads"))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Write() did not return for an unbuffered channel without a reader")
+	}
+
+	if writer.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", writer.Dropped())
+	}
+}
+
+// TestChannelWriter_Block tests the Block overflow policy.
+// It verifies that a write waits until the consumer makes room instead of dropping.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestChannelWriter_Block(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan string, 1)
+	writer := NewChannelWriter(ch, WithOverflowPolicy(Block))
+	writer.Write([]byte("first"))
+
+	done := make(chan struct{})
+	go func() {
+		writer.Write([]byte("second"))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Write() should block while the channel is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if msg := <-ch; msg != "first" {
+		t.Errorf("Channel contained %q, want %q", msg, "first")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Write() did not return after the channel had room")
+	}
+
+	if msg := <-ch; msg != "second" {
+		t.Errorf("Channel contained %q, want %q", msg, "second")
+	}
+
+	if writer.Dropped() != 0 {
+		t.Errorf("Dropped() = %d, want 0", writer.Dropped())
+	}
+}
+
+// TestOverflowPolicy_String tests the String method of OverflowPolicy.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestOverflowPolicy_String(t *testing.T) {
+	want := map[OverflowPolicy]string{
+		DropNewest:       "drop-newest",
+		DropOldest:       "drop-oldest",
+		Block:            "block",
+		BlockWithTimeout: "block-with-timeout",
+	}
+	for policy, name := range want {
+		if policy.String() != name {
+			t.Errorf("%d.String() = %q, want %q", policy, policy.String(), name)
+		}
+	}
+}
+
+// TestRecordChannelHandler_DropOldest tests the overflow policy of a record channel output.
+// It verifies that OutputOverflow is applied and drops are counted on the shared sender.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestRecordChannelHandler_DropOldest(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ch := make(chan Entry, 1)
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddRecordChannelOutput(ch, OutputOverflow(DropOldest, 0)),
+	)
+
+	testLogger.Info("first")
+	testLogger.With("k", "v").Info("second")
+
+	if entry := <-ch; entry.Message != "second" {
+		t.Errorf("Channel contained %q, want %q", entry.Message, "second")
+	}
+
+	if len(testLogger.ctx.dropCounters) != 1 || testLogger.ctx.dropCounters[0].Dropped() != 1 {
+		t.Error("The record channel output should have counted one dropped entry")
+	}
+}
+
+// TestReportDroppedMessages tests the periodic report of dropped messages.
+// It verifies that a WARN record with the number of dropped messages is written
+// to os.Stderr, that the report itself is not dropped by the full channel and
+// that reporting stops when the logger is closed.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReportDroppedMessages(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	// The reporter writes to the os.Stderr of the time the logger is created
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatalf("Failed to create the stderr file: %v", err)
+	}
+	defer stderr.Close()
+	suppressed := os.Stderr
+	os.Stderr = stderr
+	defer func() { os.Stderr = suppressed }()
+
+	full := make(chan string, 1)
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddChannelOutput(full),
+		ReportDroppedMessages(10*time.Millisecond),
+	)
+	os.Stderr = suppressed
+
+	testLogger.Info("fills the channel")
+	testLogger.Info("dropped one")
+	testLogger.Info("dropped two")
+
+	report := func() string {
+		data, _ := os.ReadFile(stderr.Name())
+		return string(data)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(report(), "msg=Log messages dropped") {
+		if time.Now().After(deadline) {
+			t.Fatal("Timeout waiting for drop report")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := report(); !strings.Contains(got, "level=WARN") || !strings.Contains(got, "dropped=2 total_dropped=2") {
+		t.Errorf("Unexpected drop report: %q", got)
+	}
+
+	// The report must not count as a dropped message and trigger another one
+	time.Sleep(50 * time.Millisecond)
+	if got := strings.Count(report(), "Log messages dropped"); got != 1 {
+		t.Errorf("Got %d drop reports, want 1: %q", got, report())
+	}
+
+	if err := testLogger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Give a report that was already running time to finish, then expect silence
+	time.Sleep(20 * time.Millisecond)
+	before := report()
+	testLogger.Info("dropped after close")
+	time.Sleep(50 * time.Millisecond)
+	if got := report(); got != before {
+		t.Errorf("Unexpected drop report after Close: %q", strings.TrimPrefix(got, before))
+	}
+}
@@ -0,0 +1,228 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the printf-style logging methods, which format the
+// message with fmt.Sprintf and record the source of their caller, and their
+// variants taking a context.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Tracef logs a formatted message like Trace, including a stack trace.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) Tracef(format string, args ...any) {
+	if !l.Enabled(context.Background(), LevelTrace) {
+		return
+	}
+	l.logTrace(context.Background(), fmt.Sprintf(format, args...), nil)
+}
+
+// TracefContext logs a formatted message like TraceContext, including a stack trace.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) TracefContext(ctx context.Context, format string, args ...any) {
+	if !l.Enabled(ctx, LevelTrace) {
+		return
+	}
+	l.logTrace(ctx, fmt.Sprintf(format, args...), nil)
+}
+
+// Debugf logs a formatted message at DEBUG level.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) Debugf(format string, args ...any) {
+	l.logf(context.Background(), slog.LevelDebug, format, args)
+}
+
+// DebugfContext logs a formatted message at DEBUG level with the given context.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) DebugfContext(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, slog.LevelDebug, format, args)
+}
+
+// Infof logs a formatted message at INFO level.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) Infof(format string, args ...any) {
+	l.logf(context.Background(), slog.LevelInfo, format, args)
+}
+
+// InfofContext logs a formatted message at INFO level with the given context.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) InfofContext(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, slog.LevelInfo, format, args)
+}
+
+// Warnf logs a formatted message at WARN level.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) Warnf(format string, args ...any) {
+	l.logf(context.Background(), slog.LevelWarn, format, args)
+}
+
+// WarnfContext logs a formatted message at WARN level with the given context.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) WarnfContext(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, slog.LevelWarn, format, args)
+}
+
+// Errorf logs a formatted message at ERROR level.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) Errorf(format string, args ...any) {
+	l.logf(context.Background(), slog.LevelError, format, args)
+}
+
+// ErrorfContext logs a formatted message at ERROR level with the given context.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None
+func (l *Logger) ErrorfContext(ctx context.Context, format string, args ...any) {
+	l.logf(ctx, slog.LevelError, format, args)
+}
+
+// Fatalf logs a formatted message like Fatal and exits the program.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None: This function does not return as it calls os.Exit
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.logTerminal(context.Background(), LevelFatal, fmt.Sprintf(format, args...), nil)
+	l.shutdown()
+	osExit(l.exitCode())
+}
+
+// FatalfContext logs a formatted message like FatalContext and exits the program.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None: This function does not return as it calls os.Exit
+func (l *Logger) FatalfContext(ctx context.Context, format string, args ...any) {
+	l.logTerminal(ctx, LevelFatal, fmt.Sprintf(format, args...), nil)
+	l.shutdown()
+	osExit(l.exitCode())
+}
+
+// Panicf logs a formatted message like Panic and then panics with it.
+//
+// Parameters:
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None: This function does not return as it panics with the message
+func (l *Logger) Panicf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	l.logTerminal(context.Background(), LevelPanic, msg, nil)
+	l.flushBeforePanic()
+	panic(msg)
+}
+
+// PanicfContext logs a formatted message like PanicContext and then panics with it.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+//
+// Returns:
+//   - None: This function does not return as it panics with the message
+func (l *Logger) PanicfContext(ctx context.Context, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	l.logTerminal(ctx, LevelPanic, msg, nil)
+	l.flushBeforePanic()
+	panic(msg)
+}
+
+// logf logs a formatted message at the given level with the source of the
+// caller of the printf-style method. The message is only formatted if the
+// level is enabled.
+//
+// Parameters:
+//   - ctx: The context for the logging operation, or nil for context.Background()
+//   - level: The level of the record
+//   - format: The format string as for fmt.Sprintf
+//   - args: The arguments for the format string
+func (l *Logger) logf(ctx context.Context, level slog.Level, format string, args []any) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip logf and the printf-style method
+	rec := slog.NewRecord(timeNow(), level, fmt.Sprintf(format, args...), l.callerPC(2))
+	if l.stackAt(level) {
+		rec.AddAttrs(slog.Any(StackKey, l.recordStack(rec, 2)))
+	}
+	_ = l.Handler().Handle(ctx, rec)
+}
@@ -0,0 +1,150 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the printf-style logging methods.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestPrintfMethods tests that the printf-style methods format the message,
+// log at their level and record the line of their caller as the source.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPrintfMethods(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	tests := []struct {
+		level string
+		log   func(l *Logger) int
+	}{
+		{"TRACE", func(l *Logger) int { l.Tracef("value %d", 1); return CallerLine() }},
+		{"DEBUG", func(l *Logger) int { l.Debugf("value %d", 1); return CallerLine() }},
+		{"INFO", func(l *Logger) int { l.Infof("value %d", 1); return CallerLine() }},
+		{"WARN", func(l *Logger) int { l.Warnf("value %d", 1); return CallerLine() }},
+		{"ERROR", func(l *Logger) int { l.Errorf("value %d", 1); return CallerLine() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			w := newOpenWriter()
+			testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), AddSource(), SetLevel(LevelTrace))
+
+			want := fmt.Sprintf("level=%s msg=value 1 source=%s:%d", tt.level, CallerFile(), tt.log(testLogger))
+			if got := w.String(); !strings.Contains(got, want) {
+				t.Errorf("Output = %q, want %q", got, want)
+			}
+		})
+	}
+
+	// Disabled levels are not formatted
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w))
+	testLogger.Debugf("%v", formatSpy{t})
+	if w.String() != "" {
+		t.Errorf("Output = %q, want nothing below the level", w.String())
+	}
+}
+
+// TestPrintfContextMethods tests that the printf-style methods with a context
+// pass it to the context extractors and honor the level set with WithLevel.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPrintfContextMethods(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	ctx := context.WithValue(context.Background(), contextKey("request_id"), "req-1")
+	ctx = WithLevel(ctx, LevelTrace)
+
+	tests := []struct {
+		level string
+		log   func(l *Logger) int
+	}{
+		{"TRACE", func(l *Logger) int { l.TracefContext(ctx, "value %d", 1); return CallerLine() }},
+		{"DEBUG", func(l *Logger) int { l.DebugfContext(ctx, "value %d", 1); return CallerLine() }},
+		{"INFO", func(l *Logger) int { l.InfofContext(ctx, "value %d", 1); return CallerLine() }},
+		{"WARN", func(l *Logger) int { l.WarnfContext(ctx, "value %d", 1); return CallerLine() }},
+		{"ERROR", func(l *Logger) int { l.ErrorfContext(ctx, "value %d", 1); return CallerLine() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			w := newOpenWriter()
+			testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), AddSource(), AddContextExtractor(requestIDExtractor))
+
+			want := fmt.Sprintf("level=%s msg=value 1 source=%s:%d request_id=req-1", tt.level, CallerFile(), tt.log(testLogger))
+			if got := w.String(); !strings.Contains(got, want) {
+				t.Errorf("Output = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// TestFatalf tests that Fatalf logs the formatted message and exits.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestFatalf(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	originalOsExit := osExit
+	defer func() { osExit = originalOsExit }()
+
+	exitCode := -1
+	osExit = func(code int) { exitCode = code }
+
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), AddSource())
+	testLogger.Fatalf("failed after %d attempts", 3)
+	want := fmt.Sprintf("level=FATAL msg=failed after 3 attempts source=%s:%d", CallerFile(), CallerLine()-1)
+
+	if exitCode != 1 {
+		t.Errorf("Fatalf() called osExit with code %d, want 1", exitCode)
+	}
+	if got := w.String(); !strings.Contains(got, want) {
+		t.Errorf("Output = %q, want %q", got, want)
+	}
+}
+
+// TestPanicf tests that Panicf logs the formatted message and panics with it.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestPanicf(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w))
+
+	defer func() {
+		if r := recover(); r != "invalid state 7" {
+			t.Errorf("Panicf() panicked with %v, want %q", r, "invalid state 7")
+		}
+		if !strings.Contains(w.String(), "level=PANIC msg=invalid state 7") {
+			t.Errorf("Panicf message not logged: %q", w.String())
+		}
+	}()
+
+	testLogger.Panicf("invalid state %d", 7)
+}
+
+// formatSpy fails the test if it is formatted.
+type formatSpy struct {
+	t *testing.T
+}
+
+// String fails the test because the value should not have been formatted.
+//
+// Returns:
+//   - string: An empty string
+func (s formatSpy) String() string {
+	s.t.Error("Message of a disabled level was formatted")
+	return ""
+}
@@ -0,0 +1,192 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the runtime reconfiguration of loggers, including the
+// watcher that reloads a configuration file when it changes.
+package logo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reconfigure replaces the configuration of the global logger (as returned by L())
+// with the given options. Loggers obtained from L() before the call use the new
+// configuration as well. If the global logger has not been initialized yet,
+// Reconfigure initializes it like InitE.
+//
+// Parameters:
+//   - opts: The options configuring the logger, applied to the defaults as in Init
+//
+// Returns:
+//   - error: The errors of all failing options joined together, or nil
+func Reconfigure(opts ...LoggerOption) error {
+	l := L()
+	if l == nil {
+		return InitE(opts...)
+	}
+	return l.Reconfigure(opts...)
+}
+
+// Reconfigure atomically replaces the outputs and settings of a live logger.
+// The options are applied to the defaults as in NewLogger, so the new
+// configuration does not inherit anything from the previous one. Log calls in
+// progress finish on the previous handlers, which are closed once they are
+// done, while all later calls use the new ones right away. If any option is
+// invalid the logger keeps its current configuration, and the log files and
+// directories created by the new options are removed again.
+//
+// Parameters:
+//   - opts: The options configuring the logger
+//
+// Returns:
+//   - error: The errors of all failing options joined together, or an error
+//     closing the previous outputs
+func (l *Logger) Reconfigure(opts ...LoggerOption) error {
+	if l == nil || l.root == nil {
+		return errors.New("logger was not created by NewLogger and cannot be reconfigured")
+	}
+	return l.reconfigure(nil, opts)
+}
+
+// reconfigure replaces the configuration of the logger with the options.
+//
+// Parameters:
+//   - stop: If closed before the configuration is replaced, nothing is replaced;
+//     used by background work that must not revive a closed logger
+//   - opts: The options configuring the logger
+//
+// Returns:
+//   - error: The errors of all failing options joined together, or an error
+//     closing the previous outputs
+func (l *Logger) reconfigure(stop <-chan struct{}, opts []LoggerOption) error {
+	ctx := newLoggerContext(opts...)
+	if err := ctx.err(); err != nil {
+		ctx.discard()
+		return err
+	}
+
+	l.root.state.mu.Lock()
+	defer l.root.state.mu.Unlock()
+
+	// Closers run while holding the lock, so stop cannot be closed concurrently
+	select {
+	case <-stop:
+		ctx.discard()
+		return nil
+	default:
+	}
+
+	l.root.state.levels.reset(ctx.namedLevels)
+	old := l.root.replace(ctx.newHandler(), ctx)
+
+	// Queued records of the previous configuration are written while closing it
+	err := old.ctx.close()
+	l.start(ctx)
+	return err
+}
+
+// WatchConfigFile configures the logger from a JSON or YAML file like
+// FromConfigFile and reconfigures it whenever the file changes. The file is
+// checked for a new modification time or size every interval, without
+// depending on file system notifications. On a change all options of the
+// logger are applied again with Reconfigure; if the file is invalid, an error
+// is logged and the previous configuration is kept. Watching stops when the
+// logger is closed.
+//
+// Parameters:
+//   - path: The path of the configuration file
+//   - interval: How often to check the file for changes
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to load and watch the configuration file
+func WatchConfigFile(path string, interval time.Duration) LoggerOption {
+	return func(ctx *loggerContext) {
+		if interval <= 0 {
+			ctx.addError(fmt.Errorf("%w: WatchConfigFile(%q): interval must be positive, got %v", ErrInvalidOption, path, interval))
+			return
+		}
+
+		// Record the state before reading so that changes made meanwhile are reloaded
+		ctx.watch = &configWatch{
+			path:     path,
+			interval: interval,
+			state:    statFile(path),
+		}
+		FromConfigFile(path)(ctx)
+	}
+}
+
+// fileState identifies a version of a watched file.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime int64
+}
+
+// statFile returns the current state of a file.
+//
+// Parameters:
+//   - path: The path of the file
+//
+// Returns:
+//   - fileState: The size and modification time, or the zero state if the file does not exist
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{exists: true, size: info.Size(), modTime: info.ModTime().UnixNano()}
+}
+
+// configWatch polls a configuration file for changes.
+type configWatch struct {
+	path     string
+	interval time.Duration
+	state    fileState
+}
+
+// start starts the goroutine that polls the file and reconfigures the logger.
+// The goroutine is stopped by the closer it registers on the logger context.
+// The closer does not wait for the goroutine, because it is also called by
+// the Reconfigure the goroutine started.
+//
+// Parameters:
+//   - l: The logger to reconfigure
+//   - ctx: The logger context holding the options to apply again
+func (w *configWatch) start(l *Logger, ctx *loggerContext) {
+	done := make(chan struct{})
+	var once sync.Once
+	ctx.closers = append(ctx.closers, func() error {
+		once.Do(func() { close(done) })
+		return nil
+	})
+
+	opts := ctx.opts
+	state := w.state
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				current := statFile(w.path)
+				if current == state {
+					continue
+				}
+				state = current
+
+				// On success the new configuration starts its own watcher and closes this one
+				if err := l.reconfigure(done, opts); err != nil {
+					l.Error("Reloading logger configuration failed", "path", w.path, "error", err)
+				}
+			}
+		}
+	}()
+}
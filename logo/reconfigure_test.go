@@ -0,0 +1,349 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the runtime reconfiguration of loggers and the
+// configuration file watcher.
+package logo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newOpenWriter creates a concurrency-safe writer that records everything written to it.
+//
+// Returns:
+//   - *gatedWriter: A writer whose gate is already open
+func newOpenWriter() *gatedWriter {
+	w := &gatedWriter{gate: make(chan struct{})}
+	close(w.gate)
+	return w
+}
+
+// TestReconfigure tests replacing the configuration of a live logger.
+// It verifies that later records, including those of derived loggers, use the
+// new outputs and level, and that invalid options keep the old configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	before, after := newOpenWriter(), newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(before))
+	child := testLogger.With("component", "db").WithGroup("query")
+
+	testLogger.Info("first")
+	if err := testLogger.Reconfigure(
+		DisableConsole(),
+		AddWriterOutput(after),
+		SetLevel(slog.LevelDebug),
+	); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	child.Debug("second", "table", "users")
+
+	if got := before.String(); !strings.Contains(got, "msg=first") || strings.Contains(got, "second") {
+		t.Errorf("Old output got %q, want only the first record", got)
+	}
+	if got := after.String(); !strings.Contains(got, "msg=second") || !strings.Contains(got, "component=db") || !strings.Contains(got, "query.table=users") {
+		t.Errorf("New output got %q, want the second record with the derived attributes", got)
+	}
+	if GetCurrentLevel(testLogger) != slog.LevelDebug {
+		t.Errorf("GetCurrentLevel() = %v, want %v", GetCurrentLevel(testLogger), slog.LevelDebug)
+	}
+
+	err := testLogger.Reconfigure(EnableAsync(-1, Block))
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Fatalf("Reconfigure() error = %v, want ErrInvalidOption", err)
+	}
+	testLogger.Debug("third")
+	if !strings.Contains(after.String(), "msg=third") {
+		t.Errorf("Failed Reconfigure() replaced the configuration, output %q", after.String())
+	}
+}
+
+// TestReconfigure_FailureDiscardsFiles tests that a failing Reconfigure removes
+// the log files and directories its options created.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure_FailureDiscardsFiles(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.log")
+	if err := os.WriteFile(existing, nil, 0644); err != nil {
+		t.Fatalf("Failed to create %s: %v", existing, err)
+	}
+
+	before := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(before))
+
+	for _, failing := range []LoggerOption{EnableAsync(-1, Block), UseCustomHandler(slog.NewTextHandler(before, nil))} {
+		err := testLogger.Reconfigure(
+			DisableConsole(),
+			AddFileOutput(filepath.Join(dir, "new", "nested", "app.log"), 1, 1, 1, false),
+			AddFileOutput(existing, 1, 1, 1, false),
+			UseJSON(true),
+			failing,
+		)
+		if err == nil {
+			t.Fatal("Reconfigure() error = nil, want an error")
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "new")); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Failed Reconfigure() left the created log directory behind: %v", err)
+		}
+		if _, err := os.Stat(existing); err != nil {
+			t.Errorf("Failed Reconfigure() removed a log file it did not create: %v", err)
+		}
+	}
+
+	testLogger.Info("still configured")
+	if !strings.Contains(before.String(), "msg=still configured") {
+		t.Errorf("Failed Reconfigure() replaced the configuration, output %q", before.String())
+	}
+}
+
+// TestReconfigure_ClosesPreviousOutputs tests that Reconfigure writes the queued
+// records of the previous configuration before closing it.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure_ClosesPreviousOutputs(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := &gatedWriter{gate: make(chan struct{})}
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), EnableAsync(10, Block))
+	testLogger.Info("queued")
+
+	done := make(chan error)
+	go func() { done <- testLogger.Reconfigure(DisableConsole()) }()
+
+	close(w.gate)
+	if err := <-done; err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	if !strings.Contains(w.String(), "msg=queued") {
+		t.Errorf("Queued record was lost by Reconfigure(): %q", w.String())
+	}
+}
+
+// TestReconfigure_InFlightRecord tests reconfiguring a logger while a record
+// is stalled in an output of the previous configuration.
+// It verifies that later records use the new configuration without waiting and
+// that Reconfigure returns once the stalled record has been written.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure_InFlightRecord(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	stalled, after := &gatedWriter{gate: make(chan struct{})}, newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(stalled))
+	old := testLogger.root.current()
+
+	go testLogger.Info("stalled")
+	waitFor(t, "the record to reach the output", func() bool {
+		old.mu.Lock()
+		defer old.mu.Unlock()
+		return old.inFlight == 1
+	})
+
+	done := make(chan error)
+	go func() { done <- testLogger.Reconfigure(DisableConsole(), AddWriterOutput(after)) }()
+	waitFor(t, "the new configuration", func() bool { return testLogger.root.current() != old })
+
+	testLogger.Info("meanwhile")
+	if !strings.Contains(after.String(), "msg=meanwhile") {
+		t.Errorf("New output got %q, want the record logged while Reconfigure waits", after.String())
+	}
+	select {
+	case <-done:
+		t.Fatal("Reconfigure() returned before the stalled record was written")
+	default:
+	}
+
+	close(stalled.gate)
+	if err := <-done; err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	if !strings.Contains(stalled.String(), "msg=stalled") {
+		t.Errorf("Old output got %q, want the stalled record", stalled.String())
+	}
+}
+
+// waitFor polls the condition until it holds, failing the test after a second.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+//   - what: A description of what is awaited, used in the failure message
+//   - cond: The condition to wait for
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timeout waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestReconfigure_Concurrent tests reconfiguring a logger while other
+// goroutines are logging. Run with -race to detect unsynchronized access.
+// It verifies that every record is written to exactly one of the configurations.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure_Concurrent(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	const goroutines, records = 4, 200
+
+	writers := []*gatedWriter{newOpenWriter()}
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(writers[0]))
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < records; i++ {
+				testLogger.Info("record")
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		w := newOpenWriter()
+		writers = append(writers, w)
+		if err := testLogger.Reconfigure(DisableConsole(), AddWriterOutput(w)); err != nil {
+			t.Fatalf("Reconfigure() error = %v", err)
+		}
+		SetLoggerLevel(testLogger, slog.LevelInfo)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, w := range writers {
+		total += strings.Count(w.String(), "msg=record")
+	}
+	if total != goroutines*records {
+		t.Errorf("Got %d records across all configurations, want %d", total, goroutines*records)
+	}
+}
+
+// TestReconfigure_Global tests the package-level Reconfigure function.
+// It verifies that loggers obtained from L() before the call use the new configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestReconfigure_Global(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	Init(DisableConsole())
+	log := L()
+
+	w := newOpenWriter()
+	if err := Reconfigure(DisableConsole(), AddWriterOutput(w)); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	log.Info("through the old reference")
+
+	if L() != log {
+		t.Error("Reconfigure() replaced the global logger instead of reconfiguring it")
+	}
+	if !strings.Contains(w.String(), "through the old reference") {
+		t.Errorf("Output got %q, want the record", w.String())
+	}
+}
+
+// TestWatchConfigFile tests that a watched configuration file is reloaded
+// when it changes and that invalid changes keep the previous configuration.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestWatchConfigFile(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "app.log")
+	configPath := writeConfigFile(t, "logger.yaml", fmt.Sprintf(`
+level: info
+outputs:
+  - type: file
+    path: %s
+`, logPath))
+
+	// writeConfig replaces the configuration and moves its modification time forward
+	modified := time.Now()
+	writeConfig := func(level string) {
+		content := fmt.Sprintf("level: %s\noutputs:\n  - type: file\n    path: %s\n", level, logPath)
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		modified = modified.Add(time.Second)
+		if err := os.Chtimes(configPath, modified, modified); err != nil {
+			t.Fatalf("Failed to change modification time: %v", err)
+		}
+	}
+
+	// waitForLog waits until the message shows up in the log file, logging it
+	// at DEBUG on every attempt if requested
+	waitForLog := func(msg string, debug bool) bool {
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if debug {
+				L().Debug(msg)
+			}
+			if data, _ := os.ReadFile(logPath); strings.Contains(string(data), msg) {
+				return true
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return false
+	}
+
+	if err := InitE(WatchConfigFile(configPath, 10*time.Millisecond)); err != nil {
+		t.Fatalf("InitE() error = %v", err)
+	}
+	defer Close()
+
+	L().Debug("before reload")
+	writeConfig("debug")
+	if !waitForLog("after reload", true) {
+		t.Fatal("Changed config file was not reloaded")
+	}
+
+	writeConfig("verbose")
+	if !waitForLog("Reloading logger configuration failed", false) {
+		t.Fatal("Invalid config file was not reported")
+	}
+	if GetCurrentLevel(L()) != slog.LevelDebug {
+		t.Errorf("Invalid config file changed the level to %v", GetCurrentLevel(L()))
+	}
+
+	data, _ := os.ReadFile(logPath)
+	if strings.Contains(string(data), "before reload") {
+		t.Error("Record below the initial level was written")
+	}
+
+	if _, err := NewLoggerE(WatchConfigFile(configPath, 0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("WatchConfigFile() with zero interval error = %v, want ErrInvalidOption", err)
+	}
+}
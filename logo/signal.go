@@ -0,0 +1,136 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the signal handling which allows the level of a running
+// logger to be stepped up and down, e.g. with SIGUSR1 and SIGUSR2.
+package logo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// signalNotify and signalStop point to the os/signal functions to allow for
+// testing without delivering real signals to the process.
+var (
+	signalNotify = signal.Notify
+	signalStop   = signal.Stop
+)
+
+// signalLevels are the levels EnableSignalLevelControl steps through, from the
+// most to the least verbose.
+var signalLevels = []slog.Level{
+	LevelTrace,
+	slog.LevelDebug,
+	slog.LevelInfo,
+	slog.LevelWarn,
+	slog.LevelError,
+	LevelFatal,
+}
+
+// EnableSignalLevelControl changes the level of the logger when the process
+// receives one of the signals. The more signal lowers the level by one step
+// towards TRACE, so more is logged; the less signal raises it towards FATAL.
+// The level stays at TRACE and FATAL once it has been reached. Every change is
+// logged as an INFO record, which is written regardless of the new level.
+// The signals are no longer handled after the logger is closed.
+//
+// Example:
+//
+//	logo.Init(logo.EnableSignalLevelControl(syscall.SIGUSR1, syscall.SIGUSR2))
+//
+// Parameters:
+//   - more: The signal that makes the logger more verbose
+//   - less: The signal that makes the logger less verbose
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to enable level control by signals
+func EnableSignalLevelControl(more, less os.Signal) LoggerOption {
+	return func(ctx *loggerContext) {
+		if more == nil || less == nil || more == less {
+			ctx.addError(fmt.Errorf("%w: EnableSignalLevelControl: need two different signals, got %v and %v", ErrInvalidOption, more, less))
+			return
+		}
+		ctx.levelSignals = []os.Signal{more, less}
+	}
+}
+
+// startSignalLevelControl starts the goroutine that changes the level of a
+// logger context on signals. The goroutine is stopped by the closer it
+// registers on the logger context.
+//
+// Parameters:
+//   - l: The logger used to log the level changes
+//   - ctx: The logger context whose level is changed
+func startSignalLevelControl(l *Logger, ctx *loggerContext) {
+	more, less := ctx.levelSignals[0], ctx.levelSignals[1]
+
+	signals := make(chan os.Signal, 1)
+	signalNotify(signals, more, less)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	var once sync.Once
+	ctx.closers = append(ctx.closers, func() error {
+		once.Do(func() {
+			signalStop(signals)
+			close(done)
+		})
+		<-stopped
+		return nil
+	})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-signals:
+				previous := ctx.level.Level()
+				level := stepLevel(previous, sig == more)
+				ctx.level.Set(level)
+
+				// Bypass the level check so the change is visible even at FATAL
+				rec := slog.NewRecord(timeNow(), slog.LevelInfo, "Log level changed", 0)
+				rec.AddAttrs(
+					slog.String("signal", sig.String()),
+					slog.String("previous_level", levelName(previous)),
+					slog.String("new_level", levelName(level)),
+				)
+				_ = l.Handler().Handle(context.Background(), rec)
+			}
+		}
+	}()
+}
+
+// stepLevel returns the next level of signalLevels in the given direction.
+// Levels between the steps move to the nearest step.
+//
+// Parameters:
+//   - level: The current level
+//   - verbose: True to lower the level towards TRACE, false to raise it towards FATAL
+//
+// Returns:
+//   - slog.Level: The new level, or the current one if there is no further step
+func stepLevel(level slog.Level, verbose bool) slog.Level {
+	if verbose {
+		for i := len(signalLevels) - 1; i >= 0; i-- {
+			if signalLevels[i] < level {
+				return signalLevels[i]
+			}
+		}
+		return level
+	}
+
+	for _, step := range signalLevels {
+		if step > level {
+			return step
+		}
+	}
+	return level
+}
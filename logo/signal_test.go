@@ -0,0 +1,110 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the level control by signals.
+package logo
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestEnableSignalLevelControl tests stepping the level with signals.
+// It verifies the direction of both signals, the limits at TRACE and FATAL,
+// the logged transitions and that Close stops the signal handling.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestEnableSignalLevelControl(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	// Capture the signal channel instead of handling real signals
+	originalNotify, originalStop := signalNotify, signalStop
+	defer func() { signalNotify, signalStop = originalNotify, originalStop }()
+
+	var signals chan<- os.Signal
+	stopped := false
+	signalNotify = func(c chan<- os.Signal, sig ...os.Signal) { signals = c }
+	signalStop = func(c chan<- os.Signal) { stopped = true }
+
+	more, less := syscall.SIGHUP, syscall.SIGTERM
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		EnableSignalLevelControl(more, less),
+	)
+
+	// send delivers a signal and waits until the level has changed to want
+	send := func(sig os.Signal, want slog.Level) {
+		t.Helper()
+		signals <- sig
+		deadline := time.Now().Add(5 * time.Second)
+		for GetCurrentLevel(testLogger) != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("After %v level is %v, want %v", sig, GetCurrentLevel(testLogger), want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	send(more, slog.LevelDebug)
+	send(more, LevelTrace)
+	send(less, slog.LevelDebug)
+	for _, want := range []slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError, LevelFatal} {
+		send(less, want)
+	}
+
+	// The change to FATAL is logged even though INFO records are now disabled
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(w.String(), "new_level=FATAL previous_level=ERROR") {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level change was not logged: %q", w.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := testLogger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !stopped {
+		t.Error("Close() did not stop the signal handling")
+	}
+}
+
+// TestStepLevel tests the level steps used by the signal level control.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestStepLevel(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	tests := []struct {
+		level   slog.Level
+		verbose bool
+		want    slog.Level
+	}{
+		{slog.LevelInfo, true, slog.LevelDebug},
+		{slog.LevelInfo, false, slog.LevelWarn},
+		{LevelTrace, true, LevelTrace},
+		{LevelFatal, false, LevelFatal},
+		{slog.LevelInfo + 2, true, slog.LevelInfo},
+		{slog.LevelInfo + 2, false, slog.LevelWarn},
+	}
+	for _, tt := range tests {
+		if got := stepLevel(tt.level, tt.verbose); got != tt.want {
+			t.Errorf("stepLevel(%v, %v) = %v, want %v", tt.level, tt.verbose, got, tt.want)
+		}
+	}
+
+	_, err := NewLoggerE(EnableSignalLevelControl(syscall.SIGHUP, syscall.SIGHUP))
+	if !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("EnableSignalLevelControl() with the same signal twice error = %v, want ErrInvalidOption", err)
+	}
+}
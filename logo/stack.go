@@ -0,0 +1,347 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the structured stack traces which are attached to records
+// as a list of frames instead of the text of debug.Stack(), either of the
+// logging site or of an error that carries one.
+package logo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// StackKey is the key of the attribute holding the stack trace of a record.
+const StackKey = "trace"
+
+// defaultStackDepth is the maximum number of frames in a stack trace unless
+// changed with SetMaxStackDepth.
+const defaultStackDepth = 32
+
+// logoPackage is the import path of this package, whose frames are left out of stack traces.
+var logoPackage = reflect.TypeOf(Logger{}).PkgPath()
+
+// StackFrame is a single function call of a stack trace.
+type StackFrame struct {
+	// Function is the fully qualified name of the function
+	Function string `json:"function"`
+
+	// File is the path of the source file
+	File string `json:"file"`
+
+	// Line is the line number in the source file
+	Line int `json:"line"`
+}
+
+// Stack is a stack trace, starting with the innermost call. JSON outputs
+// write it as an array of frames and text outputs as indented lines below
+// the record.
+type Stack []StackFrame
+
+// String returns the stack trace with one frame per line, for handlers that
+// do not know the Stack type.
+//
+// Returns:
+//   - string: The frames as "function (file:line)" separated by newlines
+func (s Stack) String() string {
+	var sb strings.Builder
+	for i, frame := range s {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s (%s:%d)", frame.Function, frame.File, frame.Line)
+	}
+	return sb.String()
+}
+
+// StackTracer is implemented by errors that carry the stack trace of the place
+// they were created, such as the errors returned by WithStack. When a stack
+// trace is attached to a record with such an error, the stack of the error is
+// used instead of the one of the logging site.
+type StackTracer interface {
+	// StackTrace returns the stack trace, starting with the innermost call
+	StackTrace() Stack
+}
+
+// StackCallers is implemented by errors that carry the program counters of the
+// place they were created, as returned by runtime.Callers, such as the errors
+// of github.com/go-errors/errors. They are used like the stack of a StackTracer.
+type StackCallers interface {
+	// Callers returns the program counters, starting with the innermost call
+	Callers() []uintptr
+}
+
+// stackError is an error annotated with the stack trace of its creation.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// WithStack annotates an error with the stack trace of the caller, which is
+// logged in place of the stack of the logging site. Returning
+// logo.WithStack(err) where an error occurs lets a log call further up the
+// stack show where it came from.
+//
+// Parameters:
+//   - err: The error to annotate
+//
+// Returns:
+//   - error: The annotated error, which unwraps to err, or nil if err is nil
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	pcs := make([]uintptr, defaultStackDepth+maxCallerDepth)
+	// Skip runtime.Callers and WithStack
+	n := runtime.Callers(2, pcs)
+	return &stackError{err: err, pcs: pcs[:n]}
+}
+
+// Error returns the message of the annotated error.
+//
+// Returns:
+//   - string: The error message
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the annotated error.
+//
+// Returns:
+//   - error: The annotated error
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the stack trace of the place the error was annotated.
+//
+// Returns:
+//   - Stack: The stack trace
+func (e *stackError) StackTrace() Stack {
+	return framesOf(e.pcs, len(e.pcs))
+}
+
+// StackTracesAt attaches a stack trace to every record at or above a level,
+// whichever method logs it. If an attribute of the record is an error that
+// carries a stack trace (see StackTracer), that stack is attached instead.
+// Fatal and Panic records get a stack trace with EnableStackTraces regardless
+// of the level, and Trace records always do.
+//
+// Parameters:
+//   - level: The minimum level of records with a stack trace, e.g. slog.LevelError
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the stack trace level
+func StackTracesAt(level slog.Level) LoggerOption {
+	return func(ctx *loggerContext) {
+		ctx.stackLevel = level
+	}
+}
+
+// SetMaxStackDepth limits the number of frames in the stack traces of the logger.
+// The default is 32 frames.
+//
+// Parameters:
+//   - depth: The maximum number of frames, at least 1
+//
+// Returns:
+//   - LoggerOption: A function that can be passed to Init() to set the maximum stack depth
+func SetMaxStackDepth(depth int) LoggerOption {
+	return func(ctx *loggerContext) {
+		if depth < 1 {
+			ctx.addError(fmt.Errorf("%w: SetMaxStackDepth: depth %d is less than 1", ErrInvalidOption, depth))
+			return
+		}
+		ctx.stackDepth = depth
+	}
+}
+
+// stack captures the stack trace of the caller of a logging method. Frames of
+// this package and of the runtime, the caller skip of the logger and leading
+// helper functions are left out.
+//
+// Parameters:
+//   - skip: The number of frames to skip, starting with the function calling stack
+//
+// Returns:
+//   - Stack: The stack trace, at most as deep as the configured maximum
+func (l *Logger) stack(skip int) Stack {
+	depth := l.maxStackDepth()
+
+	// Leave room for the frames that are filtered out
+	pcs := make([]uintptr, depth+maxCallerDepth)
+
+	// Skip runtime.Callers and stack
+	n := runtime.Callers(skip+2+l.callerSkip, pcs)
+	return framesOf(pcs[:n], depth)
+}
+
+// recordStack returns the stack trace to attach to a record: the one carried
+// by the first error attribute, in the order of the record, whose chain holds
+// a stack trace (see errorStack), otherwise the one of the caller of the
+// logging method.
+//
+// Parameters:
+//   - r: The record to attach the stack trace to
+//   - skip: The number of frames to skip, starting with the function calling recordStack
+//
+// Returns:
+//   - Stack: The stack trace
+func (l *Logger) recordStack(r slog.Record, skip int) Stack {
+	depth := l.maxStackDepth()
+
+	var stack Stack
+	r.Attrs(func(a slog.Attr) bool {
+		if err, ok := errorOf(a.Value); ok {
+			stack = errorStack(err, depth)
+		}
+		return stack == nil
+	})
+	if stack != nil {
+		return stack
+	}
+	return l.stack(skip + 1)
+}
+
+// stackAt reports whether records of a level get a stack trace because of StackTracesAt.
+//
+// Parameters:
+//   - level: The level of the record
+//
+// Returns:
+//   - bool: True if a stack trace is attached
+func (l *Logger) stackAt(level slog.Level) bool {
+	cfg := l.config()
+	return cfg != nil && cfg.stackLevel != nil && level >= cfg.stackLevel.Level()
+}
+
+// maxStackDepth returns the maximum number of frames in the stack traces of the logger.
+//
+// Returns:
+//   - int: The depth set with SetMaxStackDepth, or the default
+func (l *Logger) maxStackDepth() int {
+	if cfg := l.config(); cfg != nil && cfg.stackDepth > 0 {
+		return cfg.stackDepth
+	}
+	return defaultStackDepth
+}
+
+// errorStack returns the stack trace carried by an error or any error it
+// wraps, following errors.Unwrap. If several errors in the chain carry one,
+// the innermost stack is returned since it is closest to the origin. Errors
+// carry a stack trace by implementing StackTracer or StackCallers.
+//
+// Parameters:
+//   - err: The error to inspect
+//   - depth: The maximum number of frames
+//
+// Returns:
+//   - Stack: The stack trace, or nil if no error in the chain carries one
+func errorStack(err error, depth int) Stack {
+	var stack Stack
+	for ; err != nil; err = errors.Unwrap(err) {
+		if tracer, ok := err.(StackTracer); ok {
+			stack = tracer.StackTrace()
+			stack = stack[:min(len(stack), depth)]
+		} else if callers, ok := err.(StackCallers); ok {
+			pcs := callers.Callers()
+			stack = framesOf(pcs, depth)
+		}
+	}
+	return stack
+}
+
+// framesOf converts program counters to a stack trace without the frames of
+// this package, of the runtime and of leading helper functions.
+//
+// Parameters:
+//   - pcs: The program counters as returned by runtime.Callers
+//   - depth: The maximum number of frames
+//
+// Returns:
+//   - Stack: The stack trace
+func framesOf(pcs []uintptr, depth int) Stack {
+	stack := make(Stack, 0, min(len(pcs), depth))
+	frames := runtime.CallersFrames(pcs)
+	for len(stack) < depth {
+		frame, more := frames.Next()
+		if frame.Function != "" && !isInternalFrame(frame) && (len(stack) > 0 || !isHelper(frame.Function)) {
+			stack = append(stack, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// isInternalFrame reports whether a frame belongs to this package or to the runtime.
+//
+// Parameters:
+//   - frame: The frame to check
+//
+// Returns:
+//   - bool: True if the frame is left out of stack traces
+func isInternalFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, "runtime.") || strings.HasPrefix(frame.Function, logoPackage+".")
+}
+
+// stackValue reports whether a value holds a stack trace.
+//
+// Parameters:
+//   - v: The resolved attribute value
+//
+// Returns:
+//   - Stack: The stack trace
+//   - bool: True if v holds a Stack
+func stackValue(v slog.Value) (Stack, bool) {
+	if v.Kind() != slog.KindAny {
+		return nil, false
+	}
+	s, ok := v.Any().(Stack)
+	return s, ok
+}
+
+// writeStacks writes stack traces below a text record, one indented line per frame.
+//
+// Parameters:
+//   - sb: The builder holding the record
+//   - stacks: The stack traces by attribute key
+//   - key: Renders the attribute key of a stack trace
+//   - function: Renders the function of a frame
+//   - location: Renders the file and line of a frame
+func writeStacks(sb *strings.Builder, stacks map[string]Stack, key, function, location func(string) string) {
+	keys := make([]string, 0, len(stacks))
+	for k := range stacks {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		sb.WriteString("  ")
+		sb.WriteString(key(k + ":"))
+		sb.WriteString("\n")
+		for _, frame := range stacks[k] {
+			sb.WriteString("    ")
+			sb.WriteString(function(frame.Function))
+			sb.WriteString(" ")
+			sb.WriteString(location(fmt.Sprintf("(%s:%d)", frame.File, frame.Line)))
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// plain returns s unchanged; it is the style of text outputs without colors.
+//
+// Parameters:
+//   - s: The text to render
+//
+// Returns:
+//   - string: The text as is
+func plain(s string) string {
+	return s
+}
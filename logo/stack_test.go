@@ -0,0 +1,259 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the structured stack traces. They are in an
+// external test package, since the frames of package logo are left out of
+// stack traces.
+package logo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/aN0mad/go-logo/logo"
+)
+
+// testPackage is the import path of the package of these tests.
+const testPackage = "github.com/aN0mad/go-logo/logo_test"
+
+// TestStack_JSON tests that the JSON output writes stack traces as an array of
+// frames, starting with the caller and without frames of the logger or the runtime.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestStack_JSON(t *testing.T) {
+	// Suppress log output for this test
+	defer logo.SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := logo.NewLogger(
+		logo.DisableConsole(),
+		logo.AddWriterOutput(&buf, logo.OutputFormat(logo.FormatJSON)),
+		logo.SetLevel(logo.LevelTrace),
+	)
+
+	testLogger.Trace("with stack")
+	wantLine := logo.CallerLine() - 1
+
+	var entry struct {
+		Trace []logo.StackFrame `json:"trace"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", buf.String(), err)
+	}
+	if len(entry.Trace) == 0 {
+		t.Fatalf("JSON output %q has no stack frames", buf.String())
+	}
+
+	want := logo.StackFrame{Function: testPackage + ".TestStack_JSON", File: logo.CallerFile(), Line: wantLine}
+	if entry.Trace[0] != want {
+		t.Errorf("First frame = %+v, want %+v", entry.Trace[0], want)
+	}
+	for _, frame := range entry.Trace {
+		if strings.HasPrefix(frame.Function, "runtime.") || strings.HasPrefix(frame.Function, "github.com/aN0mad/go-logo/logo.") {
+			t.Errorf("Stack contains internal frame %+v", frame)
+		}
+	}
+}
+
+// TestStack_Text tests that the text output writes stack traces as indented
+// lines below the record.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestStack_Text(t *testing.T) {
+	// Suppress log output for this test
+	defer logo.SuppressLogOutput(t)()
+
+	var buf bytes.Buffer
+	testLogger := logo.NewLogger(logo.DisableConsole(), logo.AddWriterOutput(&buf), logo.EnableStackTraces())
+
+	var panicLine int
+	func() {
+		defer func() { _ = recover() }()
+		panicLine = logo.CallerLine() + 1
+		testLogger.Panic("with stack")
+	}()
+	want := fmt.Sprintf("\n  trace:\n    %[1]s.TestStack_Text.func1 (%[2]s:%[3]d)\n    %[1]s.TestStack_Text (%[2]s:%[4]d)\n",
+		testPackage, logo.CallerFile(), panicLine, logo.CallerLine()-2)
+
+	got := buf.String()
+	if !strings.Contains(got, want) {
+		t.Errorf("Text output = %q, want %q", got, want)
+	}
+	if first, _, _ := strings.Cut(got, "\n"); !strings.Contains(first, "msg=with stack") || strings.Contains(first, "trace") {
+		t.Errorf("First line = %q, want the record without the stack", first)
+	}
+	if strings.Contains(got, "runtime.") {
+		t.Errorf("Text output %q contains runtime frames", got)
+	}
+}
+
+// TestSetMaxStackDepth tests that the number of frames is limited and that
+// invalid depths are reported.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestSetMaxStackDepth(t *testing.T) {
+	// Suppress log output for this test
+	defer logo.SuppressLogOutput(t)()
+
+	entries := make(chan logo.Entry, 10)
+	testLogger := logo.NewLogger(logo.DisableConsole(), logo.AddRecordChannelOutput(entries), logo.SetLevel(logo.LevelTrace), logo.SetMaxStackDepth(1))
+
+	testLogger.Trace("one frame")
+	entry := <-entries
+	stack, ok := entry.Attrs[logo.StackKey].(logo.Stack)
+	if !ok || len(stack) != 1 || stack[0].Function != testPackage+".TestSetMaxStackDepth" {
+		t.Errorf("Stack = %v, want only the frame of the caller", entry.Attrs[logo.StackKey])
+	}
+
+	configured := logo.NewLogger(logo.DisableConsole(), logo.AddRecordChannelOutput(entries), logo.WithConfig(logo.Config{Level: "trace", MaxStackDepth: 1}))
+	configured.Trace("one frame")
+	entry = <-entries
+	if stack, _ := entry.Attrs[logo.StackKey].(logo.Stack); len(stack) != 1 {
+		t.Errorf("Stack = %v, want one frame from the configuration", entry.Attrs[logo.StackKey])
+	}
+
+	for _, opt := range []logo.LoggerOption{logo.SetMaxStackDepth(0), logo.WithConfig(logo.Config{MaxStackDepth: -1})} {
+		if _, err := logo.NewLoggerE(logo.DisableConsole(), opt); !errors.Is(err, logo.ErrInvalidOption) {
+			t.Errorf("NewLoggerE() error = %v, want ErrInvalidOption", err)
+		}
+	}
+}
+
+// failWithStack returns an error annotated with the stack of this function.
+//
+// Returns:
+//   - error: The annotated error
+func failWithStack() error {
+	return logo.WithStack(errors.New("disk full"))
+}
+
+// pcStackError is an error carrying the program counters of its stack trace.
+type pcStackError struct {
+	pcs []uintptr
+}
+
+// Error returns the error message.
+//
+// Returns:
+//   - string: The error message
+func (e *pcStackError) Error() string {
+	return "pc stack"
+}
+
+// Callers returns the program counters of the stack trace.
+//
+// Returns:
+//   - []uintptr: The program counters
+func (e *pcStackError) Callers() []uintptr {
+	return e.pcs
+}
+
+// failWithPCs returns an error carrying the program counters of this function.
+//
+// Returns:
+//   - error: The error
+func failWithPCs() error {
+	pcs := make([]uintptr, 8)
+	n := runtime.Callers(1, pcs)
+	return &pcStackError{pcs: pcs[:n]}
+}
+
+// TestStackTracesAt tests that records at or above the level get a stack
+// trace, whichever method logs them, and records below it do not.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestStackTracesAt(t *testing.T) {
+	// Suppress log output for this test
+	defer logo.SuppressLogOutput(t)()
+
+	entries := make(chan logo.Entry, 10)
+	testLogger := logo.NewLogger(logo.DisableConsole(), logo.AddRecordChannelOutput(entries), logo.StackTracesAt(slog.LevelWarn))
+
+	testLogger.Info("below")
+	if entry := <-entries; entry.Attrs[logo.StackKey] != nil {
+		t.Errorf("Record below the level has a stack trace: %v", entry.Attrs[logo.StackKey])
+	}
+
+	line := logo.CallerLine
+	tests := []struct {
+		name string
+		log  func() int
+	}{
+		{"Warn", func() int { testLogger.Warn("at"); return line() }},
+		{"ErrorContext", func() int { testLogger.ErrorContext(context.Background(), "above"); return line() }},
+		{"Errorf", func() int { testLogger.Errorf("above %d", 1); return line() }},
+		{"LogAttrs", func() int { testLogger.LogAttrs(context.Background(), slog.LevelError, "above"); return line() }},
+		{"With", func() int { testLogger.With("k", "v").Warn("at"); return line() }},
+	}
+	for _, tt := range tests {
+		wantLine := tt.log()
+		entry := <-entries
+		stack, _ := entry.Attrs[logo.StackKey].(logo.Stack)
+		if len(stack) == 0 || stack[0].File != logo.CallerFile() || stack[0].Line != wantLine {
+			t.Errorf("%s: stack = %v, want the frame at line %d first", tt.name, stack, wantLine)
+		}
+	}
+
+	configured := logo.NewLogger(logo.DisableConsole(), logo.AddRecordChannelOutput(entries), logo.WithConfig(logo.Config{StackTracesAt: "error"}))
+	configured.Warn("below")
+	configured.Error("at")
+	if entry := <-entries; entry.Attrs[logo.StackKey] != nil {
+		t.Errorf("Record below the configured level has a stack trace")
+	}
+	if entry := <-entries; entry.Attrs[logo.StackKey] == nil {
+		t.Errorf("Record at the configured level has no stack trace")
+	}
+
+	if _, err := logo.NewLoggerE(logo.DisableConsole(), logo.WithConfig(logo.Config{StackTracesAt: "loud"})); !errors.Is(err, logo.ErrInvalidOption) {
+		t.Errorf("NewLoggerE() error = %v, want ErrInvalidOption", err)
+	}
+}
+
+// TestStackTracesAt_ErrorStack tests that the stack trace of an error
+// attribute is attached instead of the one of the logging site.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestStackTracesAt_ErrorStack(t *testing.T) {
+	// Suppress log output for this test
+	defer logo.SuppressLogOutput(t)()
+
+	entries := make(chan logo.Entry, 10)
+	testLogger := logo.NewLogger(logo.DisableConsole(), logo.AddRecordChannelOutput(entries), logo.StackTracesAt(slog.LevelError), logo.SetLevel(logo.LevelTrace))
+
+	tests := []struct {
+		name     string
+		log      func()
+		function string
+	}{
+		{"logo.WithStack", func() { testLogger.Error("failed", "error", fmt.Errorf("saving: %w", failWithStack())) }, "failWithStack"},
+		{"program counters", func() { testLogger.Error("failed", "error", failWithPCs()) }, "failWithPCs"},
+		{"innermost", func() {
+			testLogger.Error("failed", "plain", errors.New("plain"), "error", logo.WithStack(fmt.Errorf("saving: %w", failWithStack())))
+		}, "failWithStack"},
+		{"Trace", func() { testLogger.Trace("failed", "error", failWithStack()) }, "failWithStack"},
+		{"no stack", func() { testLogger.Error("failed", "error", errors.New("plain")) }, "TestStackTracesAt_ErrorStack.func"},
+	}
+	for _, tt := range tests {
+		tt.log()
+		entry := <-entries
+		stack, _ := entry.Attrs[logo.StackKey].(logo.Stack)
+		if len(stack) == 0 || !strings.HasPrefix(stack[0].Function, testPackage+"."+tt.function) {
+			t.Errorf("%s: stack = %v, want %s first", tt.name, stack, tt.function)
+		}
+	}
+
+	if logo.WithStack(nil) != nil {
+		t.Error("logo.WithStack(nil) should return nil")
+	}
+}
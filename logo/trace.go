@@ -0,0 +1,195 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the trace-context support which correlates log records
+// with distributed traces through the W3C traceparent format and an adapter
+// for tracing libraries such as OpenTelemetry.
+package logo
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+)
+
+// Keys of the attributes written for the span of a record.
+const (
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceFlagsKey = "trace_flags"
+)
+
+// SpanContext identifies the span a record was logged in.
+// The IDs are lowercase hexadecimal strings as used in the W3C traceparent format.
+type SpanContext struct {
+	// TraceID is the 32 character ID of the trace
+	TraceID string
+
+	// SpanID is the 16 character ID of the span
+	SpanID string
+
+	// Flags holds the trace flags; bit 0 is set if the trace is sampled
+	Flags byte
+}
+
+// IsValid reports whether the span context has a valid trace and span ID.
+//
+// Returns:
+//   - bool: True if both IDs have the right length, are hexadecimal and are not all zeros
+func (sc SpanContext) IsValid() bool {
+	return isTraceHex(sc.TraceID, 32) && isTraceHex(sc.SpanID, 16)
+}
+
+// Attrs returns the attributes written for the span context.
+//
+// Returns:
+//   - []slog.Attr: The trace_id, span_id and trace_flags attributes, or nil if the span context is invalid
+func (sc SpanContext) Attrs() []slog.Attr {
+	if !sc.IsValid() {
+		return nil
+	}
+	return []slog.Attr{
+		slog.String(TraceIDKey, sc.TraceID),
+		slog.String(SpanIDKey, sc.SpanID),
+		slog.String(TraceFlagsKey, hex.EncodeToString([]byte{sc.Flags})),
+	}
+}
+
+// spanContextKey is the context key under which WithTraceParent stores the span context.
+type spanContextKey struct{}
+
+// WithTraceParent returns a copy of ctx that carries the span context of a
+// W3C traceparent value, typically the traceparent header of an incoming
+// request. Invalid values are ignored, as the W3C specification requires, and
+// ctx is returned unchanged.
+//
+// Parameters:
+//   - ctx: The parent context
+//   - traceparent: The traceparent value (e.g., "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+//
+// Returns:
+//   - context.Context: The context carrying the span context
+func WithTraceParent(ctx context.Context, traceparent string) context.Context {
+	sc, ok := ParseTraceParent(traceparent)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context stored with WithTraceParent.
+//
+// Parameters:
+//   - ctx: The context carrying the span context
+//
+// Returns:
+//   - SpanContext: The span context
+//   - bool: True if ctx carries a span context
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+// ParseTraceParent parses a W3C traceparent value of the form
+// version-traceid-spanid-flags. Values of future versions are accepted as long
+// as they start with the fields of version 00.
+//
+// Parameters:
+//   - traceparent: The traceparent value
+//
+// Returns:
+//   - SpanContext: The parsed span context
+//   - bool: True if the value is valid
+func ParseTraceParent(traceparent string) (SpanContext, bool) {
+	s := strings.TrimSpace(traceparent)
+
+	// version (2) - trace-id (32) - parent-id (16) - flags (2)
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return SpanContext{}, false
+	}
+
+	version := s[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return SpanContext{}, false
+	}
+	if len(s) > 55 && (version == "00" || s[55] != '-') {
+		return SpanContext{}, false
+	}
+
+	flags, err := hex.DecodeString(s[53:55])
+	if err != nil || !isLowerHex(s[53:55]) {
+		return SpanContext{}, false
+	}
+
+	sc := SpanContext{TraceID: s[3:35], SpanID: s[36:52], Flags: flags[0]}
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// TraceParentExtractor is a ContextExtractor that writes the span context
+// stored with WithTraceParent as trace_id, span_id and trace_flags attributes.
+//
+//	logger.Init(logger.AddContextExtractor(logger.TraceParentExtractor))
+//
+// Parameters:
+//   - ctx: The context of the record
+//
+// Returns:
+//   - []slog.Attr: The span attributes, or nil if ctx carries no span context
+func TraceParentExtractor(ctx context.Context) []slog.Attr {
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		return sc.Attrs()
+	}
+	return nil
+}
+
+// SpanExtractor adapts the span context of a tracing library to a
+// ContextExtractor writing the same attributes as TraceParentExtractor. This
+// keeps the library out of this package's dependencies. For OpenTelemetry:
+//
+//	logger.AddContextExtractor(logger.SpanExtractor(func(ctx context.Context) logger.SpanContext {
+//		sc := trace.SpanContextFromContext(ctx)
+//		return logger.SpanContext{TraceID: sc.TraceID().String(), SpanID: sc.SpanID().String(), Flags: byte(sc.TraceFlags())}
+//	}))
+//
+// Parameters:
+//   - spanContext: Returns the span context of a context; invalid span contexts are not written
+//
+// Returns:
+//   - ContextExtractor: The extractor to pass to AddContextExtractor
+func SpanExtractor(spanContext func(ctx context.Context) SpanContext) ContextExtractor {
+	return func(ctx context.Context) []slog.Attr {
+		return spanContext(ctx).Attrs()
+	}
+}
+
+// isTraceHex reports whether s is a trace or span ID of the given length.
+//
+// Parameters:
+//   - s: The ID
+//   - length: The required number of characters
+//
+// Returns:
+//   - bool: True if s has the length, is lowercase hexadecimal and is not all zeros
+func isTraceHex(s string, length int) bool {
+	return len(s) == length && isLowerHex(s) && strings.Trim(s, "0") != ""
+}
+
+// isLowerHex reports whether s consists of lowercase hexadecimal digits only.
+//
+// Parameters:
+//   - s: The string to check
+//
+// Returns:
+//   - bool: True if every character is in 0-9 or a-f
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the trace-context support.
+package logo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestParseTraceParent tests parsing W3C traceparent values.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestParseTraceParent(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", true},
+		{"future version with more fields", "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true},
+		{"version 00 with more fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false},
+		{"invalid version", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"zero span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"invalid flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x", false},
+		{"too short", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceParent(tt.value)
+			if ok != tt.want {
+				t.Fatalf("ParseTraceParent(%q) ok = %v, want %v", tt.value, ok, tt.want)
+			}
+			if ok && (sc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID != "00f067aa0ba902b7") {
+				t.Errorf("ParseTraceParent(%q) = %+v", tt.value, sc)
+			}
+		})
+	}
+}
+
+// TestTraceParentExtractor tests that the span of a traceparent stored in the
+// context is written with the same attributes by the text and JSON outputs.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestTraceParentExtractor(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text, jsonBuf bytes.Buffer
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddWriterOutput(&jsonBuf, OutputFormat(FormatJSON)),
+		AddContextExtractor(TraceParentExtractor),
+	)
+
+	ctx := WithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	testLogger.WithGroup("db").InfoContext(ctx, "query")
+
+	want := "span_id=00f067aa0ba902b7 trace_flags=01 trace_id=4bf92f3577b34da6a3ce929d0e0e4736"
+	if got := text.String(); !strings.Contains(got, want) {
+		t.Errorf("Text output = %q, want %q", got, want)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to decode JSON output %q: %v", jsonBuf.String(), err)
+	}
+	if entry[TraceIDKey] != "4bf92f3577b34da6a3ce929d0e0e4736" || entry[SpanIDKey] != "00f067aa0ba902b7" || entry[TraceFlagsKey] != "01" {
+		t.Errorf("JSON output = %v, want the span attributes", entry)
+	}
+
+	// An invalid traceparent leaves the context unchanged
+	if got := WithTraceParent(context.Background(), "invalid"); got != context.Background() {
+		t.Error("WithTraceParent() with an invalid value should return the context unchanged")
+	}
+}
+
+// TestSpanExtractor tests the adapter for the span contexts of tracing libraries.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestSpanExtractor(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	var text bytes.Buffer
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(&text),
+		AddContextExtractor(SpanExtractor(func(ctx context.Context) SpanContext {
+			sc, _ := ctx.Value(contextKey("span")).(SpanContext)
+			return sc
+		})),
+	)
+
+	span := SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}
+	testLogger.InfoContext(context.WithValue(context.Background(), contextKey("span"), span), "in span")
+	testLogger.Info("no span")
+
+	lines := strings.Split(strings.TrimSpace(text.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Got %d lines, want 2: %q", len(lines), text.String())
+	}
+	if !strings.Contains(lines[0], "trace_flags=00 trace_id=0af7651916cd43dd8448eb211c80319c") {
+		t.Errorf("First line = %q, want the span attributes", lines[0])
+	}
+	if strings.Contains(lines[1], TraceIDKey) {
+		t.Errorf("Second line = %q, want no span attributes for an invalid span context", lines[1])
+	}
+}
@@ -0,0 +1,184 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains the methods deriving child loggers which keep the full
+// Logger API, and the handler binding a context to a logger.
+package logo
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// With returns a child logger that includes the given attributes in every record.
+// Unlike slog.Logger.With, the child is a *Logger, so it keeps Trace, Fatal,
+// Panic, Close and the level functions, and follows Reconfigure of its root.
+//
+// Parameters:
+//   - args: The attributes to add, as alternating keys and values or slog.Attr values
+//
+// Returns:
+//   - *Logger: The child logger, or the logger itself if no attributes are given
+func (l *Logger) With(args ...any) *Logger {
+	if l == nil || len(args) == 0 {
+		return l
+	}
+	return l.derive(func(sl *slog.Logger) *slog.Logger { return sl.With(args...) })
+}
+
+// WithGroup returns a child logger that qualifies the keys of all attributes
+// added later, through With or in a log call, with the group name.
+// Like With, the child is a *Logger that keeps the full Logger API.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - *Logger: The child logger, or the logger itself if the name is empty
+func (l *Logger) WithGroup(name string) *Logger {
+	if l == nil || name == "" {
+		return l
+	}
+	return l.derive(func(sl *slog.Logger) *slog.Logger { return sl.WithGroup(name) })
+}
+
+// WithContext returns a child logger bound to a context. Records logged
+// without a context, such as with Info or Trace, are handled with the bound
+// context instead of context.Background(). A context passed explicitly, such
+// as to InfoContext, takes precedence over the bound one.
+//
+// Parameters:
+//   - ctx: The context to bind
+//
+// Returns:
+//   - *Logger: The child logger, or the logger itself if ctx is nil
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if l == nil || ctx == nil {
+		return l
+	}
+	return l.derive(func(sl *slog.Logger) *slog.Logger {
+		return slog.New(bindContext(sl.Handler(), ctx))
+	})
+}
+
+// derive returns a child logger created by applying fn to this logger. The
+// configuration, root, name and caller skip are kept, and fn is recorded so
+// Named can apply it again after adding the name outside of any group.
+//
+// Parameters:
+//   - fn: Derives the child slog.Logger
+//
+// Returns:
+//   - *Logger: The child logger
+func (l *Logger) derive(fn func(*slog.Logger) *slog.Logger) *Logger {
+	return &Logger{
+		Logger:     fn(l.Logger),
+		ctx:        l.ctx,
+		root:       l.root,
+		name:       l.name,
+		ops:        append(slices.Clip(l.ops), fn),
+		callerSkip: l.callerSkip,
+	}
+}
+
+// contextHandler is a slog.Handler that handles records logged without a
+// context with a bound context.
+type contextHandler struct {
+	next slog.Handler
+	ctx  context.Context
+}
+
+// bindContext returns a handler that uses ctx for records logged without a
+// context. A context bound before is replaced.
+//
+// Parameters:
+//   - h: The handler to wrap
+//   - ctx: The context to bind
+//
+// Returns:
+//   - slog.Handler: The wrapping handler
+func bindContext(h slog.Handler, ctx context.Context) slog.Handler {
+	if c, ok := h.(*contextHandler); ok {
+		h = c.next
+	}
+	return &contextHandler{next: h, ctx: ctx}
+}
+
+// context returns the context to handle a record with.
+//
+// Parameters:
+//   - ctx: The context passed to the log call
+//
+// Returns:
+//   - context.Context: The bound context if ctx is nil or context.Background(), otherwise ctx
+func (h *contextHandler) context(ctx context.Context) context.Context {
+	if ctx == nil || ctx == context.Background() {
+		return h.ctx
+	}
+	return ctx
+}
+
+// Enabled implements slog.Handler interface.
+// It asks the wrapped handler with the context of the record.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - level: The log level to check
+//
+// Returns:
+//   - bool: True if the log level should be processed, false otherwise
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(h.context(ctx), level)
+}
+
+// Handle implements slog.Handler interface.
+// It passes the record to the wrapped handler with the context of the record.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Any error returned by the wrapped handler
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(h.context(ctx), r)
+}
+
+// WithAttrs implements slog.Handler interface.
+// It returns a handler bound to the same context that wraps next with the attributes.
+//
+// Parameters:
+//   - attrs: The attributes to add to the handler
+//
+// Returns:
+//   - slog.Handler: A new handler instance with the attributes
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs), ctx: h.ctx}
+}
+
+// WithGroup implements slog.Handler interface.
+// It returns a handler bound to the same context that wraps next with the group.
+//
+// Parameters:
+//   - name: The group name
+//
+// Returns:
+//   - slog.Handler: A new handler that includes the specified group
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &contextHandler{next: h.next.WithGroup(name), ctx: h.ctx}
+}
+
+// withLeveler returns a handler bound to the same context that wraps next
+// with a different logger-wide level.
+//
+// Parameters:
+//   - wrap: Returns the new level given the current one
+//
+// Returns:
+//   - slog.Handler: The derived handler
+func (h *contextHandler) withLeveler(wrap func(slog.Leveler) slog.Leveler) slog.Handler {
+	return &contextHandler{next: withLeveler(h.next, wrap), ctx: h.ctx}
+}
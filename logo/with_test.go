@@ -0,0 +1,152 @@
+// Package logo provides functionality for structured logging.
+//
+// This file contains tests for the methods deriving child loggers.
+package logo
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// contextKey is the type of the context keys used in tests.
+type contextKey string
+
+// contextRecorder is a handler that records the context value "request_id"
+// of every handled record.
+type contextRecorder struct {
+	requestIDs []any
+}
+
+// Enabled reports that every level is enabled.
+//
+// Returns:
+//   - bool: Always true
+func (h *contextRecorder) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle records the request ID of the context.
+//
+// Parameters:
+//   - ctx: The context for the logging operation
+//   - r: The log record to process
+//
+// Returns:
+//   - error: Always nil
+func (h *contextRecorder) Handle(ctx context.Context, r slog.Record) error {
+	h.requestIDs = append(h.requestIDs, ctx.Value(contextKey("request_id")))
+	return nil
+}
+
+// WithAttrs returns the recorder itself.
+//
+// Returns:
+//   - slog.Handler: The recorder
+func (h *contextRecorder) WithAttrs([]slog.Attr) slog.Handler {
+	return h
+}
+
+// WithGroup returns the recorder itself.
+//
+// Returns:
+//   - slog.Handler: The recorder
+func (h *contextRecorder) WithGroup(string) slog.Handler {
+	return h
+}
+
+// TestLogger_With tests that With and WithGroup return loggers with the full
+// Logger API that follow the configuration of their root logger.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLogger_With(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(DisableConsole(), AddWriterOutput(w), SetLevel(LevelTrace))
+
+	child := testLogger.With("request_id", "req-1").WithGroup("http")
+	if child.config() != testLogger.config() {
+		t.Fatal("Derived logger does not share the configuration of its parent")
+	}
+	child.Trace("traced", "status", 200)
+
+	got := w.String()
+	if !strings.Contains(got, "request_id=req-1") || !strings.Contains(got, "http.status=200") || !strings.Contains(got, "level=TRACE") {
+		t.Errorf("Output = %q, want a TRACE record with the derived attributes", got)
+	}
+
+	SetLoggerLevel(child, slog.LevelWarn)
+	if GetCurrentLevel(testLogger) != slog.LevelWarn {
+		t.Errorf("SetLoggerLevel() on a derived logger did not change the root level")
+	}
+
+	if testLogger.With() != testLogger || testLogger.WithGroup("") != testLogger {
+		t.Error("With() without attributes and WithGroup(\"\") should return the logger itself")
+	}
+}
+
+// TestLogger_With_Named tests that attributes added to a named logger are kept
+// by its named children and that the children keep their name and level.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLogger_With_Named(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	w := newOpenWriter()
+	testLogger := NewLogger(
+		DisableConsole(),
+		AddWriterOutput(w),
+		SetLevelFor("cache.*", slog.LevelDebug),
+	)
+
+	redis := testLogger.Named("cache").With("region", "eu").Named("redis")
+	if redis.Name() != "cache.redis" {
+		t.Errorf("Name() = %q, want %q", redis.Name(), "cache.redis")
+	}
+	redis.Debug("connected")
+
+	got := w.String()
+	if !strings.Contains(got, "region=eu") || !strings.Contains(got, "logger=cache.redis") || strings.Count(got, "logger=") != 1 {
+		t.Errorf("Output = %q, want a DEBUG record with region=eu and a single logger=cache.redis", got)
+	}
+}
+
+// TestLogger_WithContext tests that a bound context is used for records logged
+// without a context and that an explicit context takes precedence.
+//
+// Parameters:
+//   - t: The testing instance used for assertions and test control
+func TestLogger_WithContext(t *testing.T) {
+	// Suppress log output for this test
+	defer SuppressLogOutput(t)()
+
+	recorder := &contextRecorder{}
+	testLogger := NewLogger(UseCustomHandler(recorder))
+
+	bound := context.WithValue(context.Background(), contextKey("request_id"), "bound")
+	explicit := context.WithValue(context.Background(), contextKey("request_id"), "explicit")
+	rebound := context.WithValue(context.Background(), contextKey("request_id"), "rebound")
+
+	child := testLogger.WithContext(bound).With("k", "v")
+	child.Info("info")
+	child.Trace("trace")
+	child.InfoContext(explicit, "explicit")
+	child.WithContext(rebound).Info("rebound")
+	testLogger.Info("unbound")
+
+	want := []any{"bound", "bound", "explicit", "rebound", nil}
+	if len(recorder.requestIDs) != len(want) {
+		t.Fatalf("Handled %d records, want %d", len(recorder.requestIDs), len(want))
+	}
+	for i, id := range recorder.requestIDs {
+		if id != want[i] {
+			t.Errorf("Record %d has request_id %v, want %v", i, id, want[i])
+		}
+	}
+}
@@ -10,19 +10,26 @@ import (
 
 // ChannelWriter is an io.Writer that sends log messages to a channel.
 // It can be used to pass log messages to custom processing routines.
+// What happens when the channel is full is selected with WithOverflowPolicy.
 type ChannelWriter struct {
-	ch chan string
+	ch     chan string
+	sender *channelSender[string]
 }
 
 // NewChannelWriter creates a new writer that sends log messages to the given channel.
+// By default, messages that do not fit into the channel are dropped.
 //
 // Parameters:
 //   - ch: The channel to which log messages will be sent
+//   - opts: Options selecting the overflow policy
 //
 // Returns:
 //   - *ChannelWriter: A channel writer that implements io.Writer
-func NewChannelWriter(ch chan string) *ChannelWriter {
-	return &ChannelWriter{ch: ch}
+func NewChannelWriter(ch chan string, opts ...ChannelOption) *ChannelWriter {
+	return &ChannelWriter{
+		ch:     ch,
+		sender: newChannelSender(ch, opts...),
+	}
 }
 
 // Write implements the io.Writer interface for ChannelWriter.
@@ -37,13 +44,16 @@ func NewChannelWriter(ch chan string) *ChannelWriter {
 func (cw *ChannelWriter) Write(p []byte) (int, error) {
 	msg := strings.TrimSpace(string(p))
 	if msg != "" {
-		select {
-		case cw.ch <- msg:
-			// Successfully sent to channel
-		default:
-			// Channel is full, drop message
-		}
+		cw.sender.send(msg)
 	}
-	// Always return original length, even if message is filtered
+	// Always return original length, even if message is filtered or dropped
 	return len(p), nil
 }
+
+// Dropped returns the number of messages this writer dropped because the channel was full.
+//
+// Returns:
+//   - uint64: The total number of dropped messages
+func (cw *ChannelWriter) Dropped() uint64 {
+	return cw.sender.Dropped()
+}
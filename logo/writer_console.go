@@ -18,6 +18,9 @@ import (
 
 // StyledConsoleWriter is an io.Writer that formats log messages with styles and colors.
 // It detects log levels and applies appropriate styling to make logs more readable.
+//
+// Deprecated: The console output now uses ConsoleHandler, which renders records
+// directly with the record's own time instead of re-parsing formatted text.
 type StyledConsoleWriter struct {
 	out io.Writer
 	ctx *loggerContext // Reference to the logger context for configuration
@@ -64,6 +67,7 @@ var logLevelStyles = map[string]lipgloss.Style{
 	"WARN":  lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
 	"ERROR": lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
 	"FATAL": lipgloss.NewStyle().Foreground(lipgloss.Color("160")).Bold(true).Underline(true),
+	"PANIC": lipgloss.NewStyle().Foreground(lipgloss.Color("201")).Bold(true).Underline(true),
 }
 
 // Write implements the io.Writer interface for StyledConsoleWriter.
@@ -105,6 +109,15 @@ func (cw *StyledConsoleWriter) Write(p []byte) (int, error) {
 	return fmt.Fprintln(cw.out, line)
 }
 
+// levelPatterns holds the compiled expressions used by detectLevel for each level name.
+var levelPatterns = func() map[string]*regexp.Regexp {
+	patterns := make(map[string]*regexp.Regexp, len(logLevelStyles))
+	for level := range logLevelStyles {
+		patterns[level] = regexp.MustCompile(`\bLEVEL=` + level + `\b`)
+	}
+	return patterns
+}()
+
 // detectLevel extracts the log level from a log message.
 // It parses the message string to find the level indicator.
 //
@@ -115,8 +128,7 @@ func (cw *StyledConsoleWriter) Write(p []byte) (int, error) {
 //   - string: The detected log level, or empty string if none found
 func detectLevel(s string) string {
 	s = strings.ToUpper(s)
-	for level := range logLevelStyles {
-		re := regexp.MustCompile(`\bLEVEL=` + level + `\b`)
+	for level, re := range levelPatterns {
 		if re.MatchString(s) {
 			return level
 		}